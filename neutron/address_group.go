@@ -0,0 +1,139 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// AddressGroup is a reusable set of IP addresses or CIDRs that security group
+// rules can match through remote_address_group_id.
+type AddressGroup struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Addresses   []string `json:"addresses,omitempty"`
+	TenantID    string   `json:"tenant_id,omitempty"`
+	ProjectID   string   `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetAddressGroups struct {
+	AddressGroups []AddressGroup `json:"address_groups"`
+}
+
+type SingleAddressGroup struct {
+	AddressGroup AddressGroup `json:"address_group"`
+}
+
+// AddressGroupUpdate holds the address group attributes to change; nil fields
+// are left as is. Addresses are changed with AddAddresses and
+// RemoveAddresses.
+type AddressGroupUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+const addressGroupExtension = "address-group"
+
+func (c *Client) AddressGroups(opts ...ListOpts) ([]AddressGroup, error) {
+	if err := c.requireExtension(addressGroupExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetAddressGroups
+	err := c.send(http.MethodGet, c.listURL("address-groups", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.AddressGroups, nil
+}
+
+func (c *Client) AddressGroup(id string) (AddressGroup, error) {
+	if id == "" {
+		return AddressGroup{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(addressGroupExtension); err != nil {
+		return AddressGroup{}, err
+	}
+
+	var r SingleAddressGroup
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/address-groups/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return AddressGroup{}, err
+	}
+	return r.AddressGroup, nil
+}
+
+func (c *Client) CreateAddressGroup(g AddressGroup) (AddressGroup, error) {
+	if err := c.requireExtension(addressGroupExtension); err != nil {
+		return AddressGroup{}, err
+	}
+
+	var r SingleAddressGroup
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/address-groups", c.URL), SingleAddressGroup{AddressGroup: g}, http.StatusCreated, &r)
+	if err != nil {
+		return AddressGroup{}, err
+	}
+	return r.AddressGroup, nil
+}
+
+func (c *Client) UpdateAddressGroup(id string, u AddressGroupUpdate) (AddressGroup, error) {
+	if id == "" {
+		return AddressGroup{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(addressGroupExtension); err != nil {
+		return AddressGroup{}, err
+	}
+
+	var r SingleAddressGroup
+	body := map[string]AddressGroupUpdate{"address_group": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/address-groups/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return AddressGroup{}, err
+	}
+	return r.AddressGroup, nil
+}
+
+func (c *Client) DeleteAddressGroup(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(addressGroupExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/address-groups/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+// AddAddresses adds addresses to the group. Neutron normalizes them to CIDRs,
+// so "10.0.0.1" is stored as "10.0.0.1/32".
+func (c *Client) AddAddresses(groupID string, addresses []string) (AddressGroup, error) {
+	return c.addressGroupAction(groupID, "add_addresses", addresses)
+}
+
+func (c *Client) RemoveAddresses(groupID string, addresses []string) (AddressGroup, error) {
+	return c.addressGroupAction(groupID, "remove_addresses", addresses)
+}
+
+func (c *Client) addressGroupAction(groupID, action string, addresses []string) (AddressGroup, error) {
+	if groupID == "" {
+		return AddressGroup{}, fmt.Errorf("empty 'groupID' parameter")
+	}
+	if len(addresses) == 0 {
+		return AddressGroup{}, fmt.Errorf("empty 'addresses' parameter")
+	}
+	if err := c.requireExtension(addressGroupExtension); err != nil {
+		return AddressGroup{}, err
+	}
+
+	var r SingleAddressGroup
+	body := map[string][]string{"addresses": addresses}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/address-groups/%s/%s", c.URL, groupID, action), body, http.StatusOK, &r)
+	if err != nil {
+		return AddressGroup{}, err
+	}
+	return r.AddressGroup, nil
+}
@@ -0,0 +1,154 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const addressGroupResp = `{
+  "address_group": {
+    "id": "9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2",
+    "name": "office",
+    "description": "",
+    "addresses": ["10.0.0.1/32", "192.168.0.0/24"]
+  }
+}`
+
+const addressGroupsResp = `{
+  "address_groups": [
+    {
+      "id": "9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2",
+      "name": "office",
+      "addresses": ["10.0.0.1/32", "192.168.0.0/24"],
+      "project_id": "45977fa2dbd7482098dd68d0d8970117"
+    },
+    {
+      "id": "0f3b3c3e-1bd3-4a2c-9d2b-5b7f1f0a7c11",
+      "name": "vpn",
+      "addresses": ["172.16.0.0/16"],
+      "project_id": "45977fa2dbd7482098dd68d0d8970117"
+    }
+  ]
+}`
+
+var _ = Describe("Address groups", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		body   []byte
+		query  string
+	)
+
+	BeforeEach(func() {
+		method, path, query, body = "", "", "", nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			method = r.Method
+			path = r.URL.Path
+			query = r.URL.RawQuery
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+				return
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+			case r.URL.Path == "/v2.0/address-groups":
+				fmt.Fprintln(w, addressGroupsResp)
+				return
+			}
+			fmt.Fprintln(w, addressGroupResp)
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("creates an address group", func() {
+		g, err := client.CreateAddressGroup(neutron.AddressGroup{Name: "office", Addresses: []string{"10.0.0.1"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/address-groups"))
+		Expect(body).To(MatchJSON(`{"address_group": {"name": "office", "addresses": ["10.0.0.1"]}}`))
+		Expect(g.Addresses).To(ContainElement("10.0.0.1/32"))
+	})
+
+	It("adds addresses", func() {
+		g, err := client.AddAddresses("9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2", []string{"192.168.0.0/24"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/address-groups/9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2/add_addresses"))
+		Expect(body).To(MatchJSON(`{"addresses": ["192.168.0.0/24"]}`))
+		Expect(g.Addresses).To(HaveLen(2))
+	})
+
+	It("removes addresses", func() {
+		_, err := client.RemoveAddresses("9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2", []string{"10.0.0.1/32"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/address-groups/9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2/remove_addresses"))
+		Expect(body).To(MatchJSON(`{"addresses": ["10.0.0.1/32"]}`))
+	})
+
+	It("requires addresses", func() {
+		_, err := client.AddAddresses("9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2", nil)
+		Expect(err).To(MatchError("empty 'addresses' parameter"))
+	})
+
+	It("lists address groups", func() {
+		groups, err := client.AddressGroups(neutron.ListOpts{ProjectID: "45977fa2dbd7482098dd68d0d8970117"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodGet))
+		Expect(path).To(Equal("/v2.0/address-groups"))
+		Expect(query).To(Equal("project_id=45977fa2dbd7482098dd68d0d8970117"))
+		Expect(groups).To(HaveLen(2))
+		Expect(groups[1].Name).To(Equal("vpn"))
+		Expect(groups[1].Addresses).To(Equal([]string{"172.16.0.0/16"}))
+	})
+
+	It("gets an address group", func() {
+		g, err := client.AddressGroup("9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/address-groups/9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2"))
+		Expect(g.Name).To(Equal("office"))
+		Expect(g.Addresses).To(Equal([]string{"10.0.0.1/32", "192.168.0.0/24"}))
+	})
+
+	It("updates an address group", func() {
+		description := "head office"
+		_, err := client.UpdateAddressGroup("9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2", neutron.AddressGroupUpdate{Description: &description})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/address-groups/9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2"))
+		Expect(body).To(MatchJSON(`{"address_group": {"description": "head office"}}`))
+	})
+
+	It("deletes an address group", func() {
+		err := client.DeleteAddressGroup("9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/address-groups/9ace7a1d-2b6a-4c1b-ac9c-f0e5d3b7e3a2"))
+	})
+
+	It("requires an id", func() {
+		_, err := client.AddressGroup("")
+		Expect(err).To(MatchError("empty 'id' parameter"))
+		err = client.DeleteAddressGroup("")
+		Expect(err).To(MatchError("empty 'id' parameter"))
+		Expect(method).To(BeEmpty())
+	})
+})
@@ -0,0 +1,178 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	DHCPAgentType     = "DHCP agent"
+	L3AgentType       = "L3 agent"
+	MetadataAgentType = "Metadata agent"
+	OVSAgentType      = "Open vSwitch agent"
+)
+
+type Agent struct {
+	ID                 string                 `json:"id"`
+	AgentType          string                 `json:"agent_type"`
+	Binary             string                 `json:"binary"`
+	Host               string                 `json:"host"`
+	Topic              string                 `json:"topic"`
+	Description        string                 `json:"description"`
+	AdminStateUp       bool                   `json:"admin_state_up"`
+	Alive              bool                   `json:"alive"`
+	AvailabilityZone   string                 `json:"availability_zone"`
+	Configurations     map[string]interface{} `json:"configurations"`
+	CreatedAt          string                 `json:"created_at"`
+	StartedAt          string                 `json:"started_at"`
+	HeartbeatTimestamp string                 `json:"heartbeat_timestamp"`
+	ResourcesSynced    *bool                  `json:"resources_synced"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetAgents struct {
+	Agents []Agent `json:"agents"`
+}
+
+type SingleAgent struct {
+	Agent Agent `json:"agent"`
+}
+
+// AgentUpdate holds the agent attributes to change; nil fields are left as is.
+type AgentUpdate struct {
+	AdminStateUp *bool   `json:"admin_state_up,omitempty"`
+	Description  *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+func (c *Client) Agents(opts ...ListOpts) ([]Agent, error) {
+	if err := c.requireExtension("agent"); err != nil {
+		return nil, err
+	}
+	return c.getAgents(c.listURL("agents", nil, opts))
+}
+
+func (c *Client) getAgents(url string) ([]Agent, error) {
+	var r GetAgents
+	err := c.send(http.MethodGet, url, nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Agents, nil
+}
+
+func (c *Client) Agent(id string) (Agent, error) {
+	if id == "" {
+		return Agent{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension("agent"); err != nil {
+		return Agent{}, err
+	}
+
+	var r SingleAgent
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/agents/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return Agent{}, err
+	}
+	return r.Agent, nil
+}
+
+func (c *Client) UpdateAgent(id string, u AgentUpdate) (Agent, error) {
+	if id == "" {
+		return Agent{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension("agent"); err != nil {
+		return Agent{}, err
+	}
+
+	var r SingleAgent
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/agents/%s", c.URL, id), map[string]AgentUpdate{"agent": u}, http.StatusOK, &r)
+	if err != nil {
+		return Agent{}, err
+	}
+	return r.Agent, nil
+}
+
+// DeleteAgent removes an agent, typically one that is no longer alive.
+func (c *Client) DeleteAgent(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension("agent"); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/agents/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) DHCPAgentNetworks(agentID string) ([]Network, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("empty 'agentID' parameter")
+	}
+	if err := c.requireExtension("dhcp_agent_scheduler"); err != nil {
+		return nil, err
+	}
+
+	var r GetNetworks
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/agents/%s/dhcp-networks", c.URL, agentID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Networks, nil
+}
+
+func (c *Client) AddNetworkToDHCPAgent(agentID, networkID string) error {
+	if agentID == "" {
+		return fmt.Errorf("empty 'agentID' parameter")
+	}
+	if networkID == "" {
+		return fmt.Errorf("empty 'networkID' parameter")
+	}
+	if err := c.requireExtension("dhcp_agent_scheduler"); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/agents/%s/dhcp-networks", c.URL, agentID), map[string]string{"network_id": networkID}, http.StatusCreated, nil)
+}
+
+func (c *Client) RemoveNetworkFromDHCPAgent(agentID, networkID string) error {
+	if agentID == "" {
+		return fmt.Errorf("empty 'agentID' parameter")
+	}
+	if networkID == "" {
+		return fmt.Errorf("empty 'networkID' parameter")
+	}
+	if err := c.requireExtension("dhcp_agent_scheduler"); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/agents/%s/dhcp-networks/%s", c.URL, agentID, networkID), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) L3AgentRouters(agentID string) ([]Router, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("empty 'agentID' parameter")
+	}
+	if err := c.requireExtension("l3_agent_scheduler"); err != nil {
+		return nil, err
+	}
+
+	var r GetRouters
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/agents/%s/l3-routers", c.URL, agentID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Routers, nil
+}
+
+func (c *Client) RouterL3Agents(routerID string) ([]Agent, error) {
+	if routerID == "" {
+		return nil, fmt.Errorf("empty 'routerID' parameter")
+	}
+	if err := c.requireExtension("l3_agent_scheduler"); err != nil {
+		return nil, err
+	}
+	return c.getAgents(fmt.Sprintf("%s/v2.0/routers/%s/l3-agents", c.URL, routerID))
+}
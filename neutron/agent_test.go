@@ -0,0 +1,232 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const agentsResp = `{
+  "agents": [
+    {
+      "binary": "neutron-dhcp-agent",
+      "description": null,
+      "availability_zone": "nova",
+      "heartbeat_timestamp": "2017-09-12 19:39:56",
+      "admin_state_up": true,
+      "resources_synced": null,
+      "alive": true,
+      "id": "840d5d68-5759-4e9e-812f-f3bd19214c7f",
+      "topic": "dhcp_agent",
+      "host": "agenthost1",
+      "agent_type": "DHCP agent",
+      "started_at": "2017-09-12 19:35:36",
+      "created_at": "2017-09-12 19:35:36",
+      "configurations": {
+        "subnets": 2,
+        "dhcp_driver": "neutron.agent.linux.dhcp.Dnsmasq",
+        "networks": 1,
+        "log_agent_heartbeats": false,
+        "ports": 3
+      }
+    }
+  ]
+}`
+
+const agentResp = `{
+  "agent": {
+    "binary": "neutron-l3-agent",
+    "description": "maintenance",
+    "availability_zone": "nova",
+    "heartbeat_timestamp": "2017-09-12 19:40:38",
+    "admin_state_up": false,
+    "alive": false,
+    "id": "04c62b91-b799-48b7-9cd5-2982db6df9c6",
+    "topic": "l3_agent",
+    "host": "agenthost2",
+    "agent_type": "L3 agent",
+    "started_at": "2017-09-12 19:35:38",
+    "created_at": "2017-09-12 19:35:38",
+    "configurations": {
+      "agent_mode": "legacy",
+      "routers": 1
+    }
+  }
+}`
+
+const l3AgentRoutersResp = `{
+  "routers": [
+    {
+      "admin_state_up": true,
+      "description": "",
+      "distributed": false,
+      "external_gateway_info": {
+        "enable_snat": true,
+        "external_fixed_ips": [
+          {
+            "ip_address": "172.24.4.3",
+            "subnet_id": "b930d7f6-ceb7-40a0-8b81-a425dd994ccf"
+          }
+        ],
+        "network_id": "ae34051f-aa6c-4c75-abf5-50dc9ac99ef3"
+      },
+      "ha": false,
+      "id": "915a14a6-867b-4af7-83d1-70efceb146f9",
+      "name": "router2",
+      "status": "ACTIVE",
+      "project_id": "0bd18306d801447bb457a46252d82d13",
+      "tenant_id": "0bd18306d801447bb457a46252d82d13"
+    }
+  ]
+}`
+
+var _ = Describe("Agents", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		query  url.Values
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			method = r.Method
+			path = r.URL.Path
+			query = r.URL.Query()
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+			case r.URL.Path == "/v2.0/agents" || r.URL.Path == "/v2.0/routers/router1/l3-agents":
+				fmt.Fprintln(w, agentsResp)
+			case r.URL.Path == "/v2.0/agents/agent1/dhcp-networks":
+				fmt.Fprintln(w, networks)
+			case r.URL.Path == "/v2.0/agents/agent1/l3-routers":
+				fmt.Fprintln(w, l3AgentRoutersResp)
+			default:
+				fmt.Fprintln(w, agentResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Agents", func() {
+		It("lists agents", func() {
+			agents, err := client.Agents()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(agents).To(HaveLen(1))
+			Expect(agents[0].ID).To(Equal("840d5d68-5759-4e9e-812f-f3bd19214c7f"))
+			Expect(agents[0].AgentType).To(Equal(neutron.DHCPAgentType))
+			Expect(agents[0].Host).To(Equal("agenthost1"))
+			Expect(agents[0].Alive).To(BeTrue())
+			Expect(agents[0].Configurations).To(HaveKeyWithValue("networks", 1.0))
+		})
+
+		It("filters agents", func() {
+			_, err := client.Agents(neutron.ListOpts{
+				Filters: map[string]string{"agent_type": neutron.L3AgentType},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(query.Get("agent_type")).To(Equal("L3 agent"))
+		})
+	})
+
+	Describe("Agent", func() {
+		It("shows an agent", func() {
+			agent, err := client.Agent("04c62b91-b799-48b7-9cd5-2982db6df9c6")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/agents/04c62b91-b799-48b7-9cd5-2982db6df9c6"))
+			Expect(agent.Description).To(Equal("maintenance"))
+			Expect(agent.AdminStateUp).To(BeFalse())
+		})
+
+		Context("when agent id is invalid", func() {
+			It("returns an error", func() {
+				_, err := client.Agent("")
+				Expect(err).To(MatchError("empty 'id' parameter"))
+			})
+		})
+	})
+
+	Describe("UpdateAgent", func() {
+		It("sends only the given attributes", func() {
+			down := false
+			_, err := client.UpdateAgent("agent1", neutron.AgentUpdate{AdminStateUp: &down})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/agents/agent1"))
+			Expect(body).To(MatchJSON(`{"agent": {"admin_state_up": false}}`))
+		})
+	})
+
+	Describe("DeleteAgent", func() {
+		It("deletes an agent", func() {
+			err := client.DeleteAgent("agent1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/agents/agent1"))
+		})
+	})
+
+	Describe("DHCP agent scheduler", func() {
+		It("lists networks hosted by a DHCP agent", func() {
+			networks, err := client.DHCPAgentNetworks("agent1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(networks).To(HaveLen(1))
+			Expect(networks[0].Name).To(Equal("public"))
+		})
+
+		It("adds a network to a DHCP agent", func() {
+			err := client.AddNetworkToDHCPAgent("agent1", "network1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(path).To(Equal("/v2.0/agents/agent1/dhcp-networks"))
+			Expect(body).To(MatchJSON(`{"network_id": "network1"}`))
+		})
+
+		It("removes a network from a DHCP agent", func() {
+			err := client.RemoveNetworkFromDHCPAgent("agent1", "network1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/agents/agent1/dhcp-networks/network1"))
+		})
+	})
+
+	Describe("L3 agent scheduler", func() {
+		It("lists routers on an L3 agent", func() {
+			routers, err := client.L3AgentRouters("agent1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(routers).To(HaveLen(1))
+			Expect(routers[0].Name).To(Equal("router2"))
+			Expect(routers[0].ExternalGatewayInfo.NetworkID).To(Equal("ae34051f-aa6c-4c75-abf5-50dc9ac99ef3"))
+		})
+
+		It("lists L3 agents hosting a router", func() {
+			agents, err := client.RouterL3Agents("router1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/routers/router1/l3-agents"))
+			Expect(agents).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type AutoAllocatedTopology struct {
+	ID        string `json:"id,omitempty"`
+	TenantID  string `json:"tenant_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+	DryRun    string `json:"dry-run,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type SingleAutoAllocatedTopology struct {
+	AutoAllocatedTopology AutoAllocatedTopology `json:"auto_allocated_topology"`
+}
+
+const autoAllocatedTopologyExtension = "auto-allocated-topology"
+
+// AutoAllocatedTopology returns the network of the auto-allocated topology of
+// the project, allocating the network, subnets and router on first use.
+func (c *Client) AutoAllocatedTopology(projectID string) (Network, error) {
+	if projectID == "" {
+		return Network{}, fmt.Errorf("empty 'projectID' parameter")
+	}
+	if err := c.requireExtension(autoAllocatedTopologyExtension); err != nil {
+		return Network{}, err
+	}
+
+	var r SingleAutoAllocatedTopology
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/auto-allocated-topology/%s", c.URL, projectID), nil, http.StatusOK, &r)
+	if err != nil {
+		return Network{}, err
+	}
+	if r.AutoAllocatedTopology.ID == "" {
+		return Network{}, fmt.Errorf("no network allocated for project '%s'", projectID)
+	}
+	return c.Network(r.AutoAllocatedTopology.ID)
+}
+
+// ValidateAutoAllocatedTopology checks, without allocating anything, that the
+// deployment can auto-allocate a topology for the project. Neutron answers
+// with a conflict error naming the missing requirement, such as a default
+// external network or subnet pool.
+func (c *Client) ValidateAutoAllocatedTopology(projectID string) error {
+	if projectID == "" {
+		return fmt.Errorf("empty 'projectID' parameter")
+	}
+	if err := c.requireExtension(autoAllocatedTopologyExtension); err != nil {
+		return err
+	}
+
+	var r SingleAutoAllocatedTopology
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/auto-allocated-topology/%s?fields=dry-run", c.URL, projectID), nil, http.StatusOK, &r)
+	if err != nil {
+		return err
+	}
+	if r.AutoAllocatedTopology.DryRun != "pass" {
+		return fmt.Errorf("auto-allocated topology dry run for project '%s' did not pass", projectID)
+	}
+	return nil
+}
+
+// DeleteAutoAllocatedTopology deletes the network, subnets and router
+// auto-allocated for the project.
+func (c *Client) DeleteAutoAllocatedTopology(projectID string) error {
+	if projectID == "" {
+		return fmt.Errorf("empty 'projectID' parameter")
+	}
+	if err := c.requireExtension(autoAllocatedTopologyExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/auto-allocated-topology/%s", c.URL, projectID), nil, http.StatusNoContent, nil)
+}
@@ -0,0 +1,101 @@
+package neutron_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const autoAllocatedTopologyResp = `{
+  "auto_allocated_topology": {
+    "id": "deea2d47-b4ef-4a5c-a3c6-e5df5bc1c3d2",
+    "tenant_id": "cfad3b7e-3cbf-4c73-a7d5-0d1e8e9c9b8a"
+  }
+}`
+
+const autoAllocatedNetworkResp = `{
+  "network": {
+    "id": "deea2d47-b4ef-4a5c-a3c6-e5df5bc1c3d2",
+    "name": "auto_allocated_network",
+    "admin_state_up": true,
+    "status": "ACTIVE"
+  }
+}`
+
+var _ = Describe("Auto-allocated topology", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		requests []string
+		dryRun   int
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		dryRun = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests = append(requests, r.Method+" "+r.URL.RequestURI())
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.URL.Query().Get("fields") == "dry-run":
+				w.WriteHeader(dryRun)
+				if dryRun == http.StatusOK {
+					fmt.Fprintln(w, `{"auto_allocated_topology": {"dry-run": "pass"}}`)
+				} else {
+					fmt.Fprintln(w, `{"NeutronError": {"message": "Deployment error: No default router:external network."}}`)
+				}
+			case r.URL.Path == "/v2.0/networks/deea2d47-b4ef-4a5c-a3c6-e5df5bc1c3d2":
+				fmt.Fprintln(w, autoAllocatedNetworkResp)
+			default:
+				fmt.Fprintln(w, autoAllocatedTopologyResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("returns the auto-allocated network", func() {
+		net, err := client.AutoAllocatedTopology("project1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal([]string{
+			"GET /v2.0/auto-allocated-topology/project1",
+			"GET /v2.0/networks/deea2d47-b4ef-4a5c-a3c6-e5df5bc1c3d2",
+		}))
+		Expect(net.Name).To(Equal("auto_allocated_network"))
+	})
+
+	It("validates the topology with a dry run", func() {
+		err := client.ValidateAutoAllocatedTopology("project1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal([]string{"GET /v2.0/auto-allocated-topology/project1?fields=dry-run"}))
+	})
+
+	It("reports failed dry runs", func() {
+		dryRun = http.StatusConflict
+		err := client.ValidateAutoAllocatedTopology("project1")
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*neutron.Error).StatusCode).To(Equal(http.StatusConflict))
+		Expect(err.Error()).To(ContainSubstring("No default router:external network"))
+	})
+
+	It("deletes the topology", func() {
+		err := client.DeleteAutoAllocatedTopology("project1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal([]string{"DELETE /v2.0/auto-allocated-topology/project1"}))
+	})
+})
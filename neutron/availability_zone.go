@@ -0,0 +1,89 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	AvailabilityZoneResourceNetwork = "network"
+	AvailabilityZoneResourceRouter  = "router"
+
+	AvailabilityZoneAvailable   = "available"
+	AvailabilityZoneUnavailable = "unavailable"
+)
+
+// AvailabilityZone is a zone of agents hosting networks or routers. Zones
+// hosting both are listed once per resource.
+type AvailabilityZone struct {
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+	State    string `json:"state"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetAvailabilityZones struct {
+	AvailabilityZones []AvailabilityZone `json:"availability_zones"`
+}
+
+// AvailabilityZones lists the zones hosting resource, one of the
+// AvailabilityZoneResource constants, in the given state. Empty resource or
+// state parameters match any value.
+func (c *Client) AvailabilityZones(resource, state string, opts ...ListOpts) ([]AvailabilityZone, error) {
+	if err := c.requireExtension("availability_zone"); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	if resource != "" {
+		q.Set("resource", resource)
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
+
+	var r GetAvailabilityZones
+	err := c.send(http.MethodGet, c.listURL("availability_zones", q, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.AvailabilityZones, nil
+}
+
+// ValidateAvailabilityZoneHints checks that each hint names an available
+// zone for resource, as Neutron rejects creations with unknown hints.
+func (c *Client) ValidateAvailabilityZoneHints(resource string, hints []string) error {
+	if len(hints) == 0 {
+		return nil
+	}
+	zones, err := c.AvailabilityZones(resource, AvailabilityZoneAvailable)
+	if err != nil {
+		return err
+	}
+	for _, h := range hints {
+		found := false
+		for _, z := range zones {
+			if z.Name == h {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("availability zone '%s' is not available for %s resources", h, resource)
+		}
+	}
+	return nil
+}
+
+// requireNetworkAvailabilityZones checks that Neutron supports availability
+// zone hints when any of nets sets them.
+func (c *Client) requireNetworkAvailabilityZones(nets ...Network) error {
+	for _, net := range nets {
+		if len(net.AvailabilityZoneHints) > 0 {
+			return c.requireExtension("network_availability_zone")
+		}
+	}
+	return nil
+}
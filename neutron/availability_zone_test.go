@@ -0,0 +1,123 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const availabilityZonesResp = `{
+  "availability_zones": [
+    {"state": "available", "resource": "router", "name": "az1"},
+    {"state": "available", "resource": "router", "name": "az2"}
+  ]
+}`
+
+const azRouterResp = `{
+  "router": {
+    "id": "915a14a6-867b-4af7-83d1-70efceb146f9",
+    "name": "router1",
+    "admin_state_up": true,
+    "status": "ACTIVE",
+    "availability_zone_hints": ["az1"],
+    "availability_zones": ["az1"]
+  }
+}`
+
+var _ = Describe("Availability zones", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		path   string
+		query  url.Values
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			path = r.URL.Path
+			query = r.URL.Query()
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.URL.Path == "/v2.0/availability_zones":
+				fmt.Fprintln(w, availabilityZonesResp)
+			case r.Method == http.MethodPost && r.URL.Path == "/v2.0/networks":
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, azRouterResp)
+			default:
+				fmt.Fprintln(w, azRouterResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists zones by resource and state", func() {
+		zones, err := client.AvailabilityZones(neutron.AvailabilityZoneResourceRouter, neutron.AvailabilityZoneAvailable)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/availability_zones"))
+		Expect(query.Get("resource")).To(Equal("router"))
+		Expect(query.Get("state")).To(Equal("available"))
+		Expect(zones).To(HaveLen(2))
+		Expect(zones[0].Name).To(Equal("az1"))
+	})
+
+	It("validates hints against available zones", func() {
+		Expect(client.ValidateAvailabilityZoneHints(neutron.AvailabilityZoneResourceRouter, []string{"az2"})).To(Succeed())
+		err := client.ValidateAvailabilityZoneHints(neutron.AvailabilityZoneResourceRouter, []string{"az3"})
+		Expect(err).To(MatchError("availability zone 'az3' is not available for router resources"))
+	})
+
+	It("creates a network with zone hints", func() {
+		net, err := client.CreateNetwork(neutron.Network{
+			Name:                  "net1",
+			AvailabilityZoneHints: []string{"az1", "az2"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{
+			"network": {"name": "net1", "admin_state_up": false, "availability_zone_hints": ["az1", "az2"]}
+		}`))
+		Expect(net.AvailabilityZoneHints).To(Equal([]string{"az1", "az2"}))
+	})
+
+	It("creates a router with zone hints", func() {
+		router, err := client.CreateRouter(neutron.Router{
+			Name:                  "router1",
+			AdminStateUp:          true,
+			AvailabilityZoneHints: []string{"az1"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/routers"))
+		Expect(body).To(MatchJSON(`{
+			"router": {"name": "router1", "admin_state_up": true, "availability_zone_hints": ["az1"]}
+		}`))
+		Expect(router.AvailabilityZones).To(Equal([]string{"az1"}))
+	})
+
+	It("reads back the zones of a router", func() {
+		router, err := client.Router("915a14a6-867b-4af7-83d1-70efceb146f9")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/routers/915a14a6-867b-4af7-83d1-70efceb146f9"))
+		Expect(router.AvailabilityZoneHints).To(Equal([]string{"az1"}))
+		Expect(router.AvailabilityZones).To(Equal([]string{"az1"}))
+	})
+})
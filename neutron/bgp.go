@@ -0,0 +1,345 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const BGPDRAgentType = "BGP dynamic routing agent"
+
+// BGPSpeaker advertises the prefixes of its gateway networks to its peers.
+type BGPSpeaker struct {
+	ID                            string   `json:"id,omitempty"`
+	Name                          string   `json:"name,omitempty"`
+	LocalAS                       int      `json:"local_as,omitempty"`
+	IPVersion                     int      `json:"ip_version,omitempty"`
+	AdvertiseFloatingIPHostRoutes *bool    `json:"advertise_floating_ip_host_routes,omitempty"`
+	AdvertiseTenantNetworks       *bool    `json:"advertise_tenant_networks,omitempty"`
+	Peers                         []string `json:"peers,omitempty"`
+	Networks                      []string `json:"networks,omitempty"`
+	TenantID                      string   `json:"tenant_id,omitempty"`
+	ProjectID                     string   `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetBGPSpeakers struct {
+	BGPSpeakers []BGPSpeaker `json:"bgp_speakers"`
+}
+
+type SingleBGPSpeaker struct {
+	BGPSpeaker BGPSpeaker `json:"bgp_speaker"`
+}
+
+// BGPSpeakerUpdate holds the BGP speaker attributes to change; nil fields are
+// left as is.
+type BGPSpeakerUpdate struct {
+	Name                          *string `json:"name,omitempty"`
+	AdvertiseFloatingIPHostRoutes *bool   `json:"advertise_floating_ip_host_routes,omitempty"`
+	AdvertiseTenantNetworks       *bool   `json:"advertise_tenant_networks,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type BGPPeer struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	PeerIP    string `json:"peer_ip,omitempty"`
+	RemoteAS  int    `json:"remote_as,omitempty"`
+	AuthType  string `json:"auth_type,omitempty"`
+	Password  string `json:"password,omitempty"`
+	TenantID  string `json:"tenant_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetBGPPeers struct {
+	BGPPeers []BGPPeer `json:"bgp_peers"`
+}
+
+type SingleBGPPeer struct {
+	BGPPeer BGPPeer `json:"bgp_peer"`
+}
+
+// BGPPeerUpdate holds the BGP peer attributes to change; nil fields are left
+// as is.
+type BGPPeerUpdate struct {
+	Name     *string `json:"name,omitempty"`
+	Password *string `json:"password,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// AdvertisedRoute is a route a BGP speaker announces to its peers.
+type AdvertisedRoute struct {
+	Destination string `json:"destination"`
+	NextHop     string `json:"next_hop"`
+}
+
+type GetAdvertisedRoutes struct {
+	AdvertisedRoutes []AdvertisedRoute `json:"advertised_routes"`
+}
+
+const (
+	bgpExtension          = "bgp"
+	bgpSchedulerExtension = "bgp_dragent_scheduler"
+)
+
+func (c *Client) BGPSpeakers(opts ...ListOpts) ([]BGPSpeaker, error) {
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetBGPSpeakers
+	err := c.send(http.MethodGet, c.listURL("bgp-speakers", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.BGPSpeakers, nil
+}
+
+func (c *Client) BGPSpeaker(id string) (BGPSpeaker, error) {
+	if id == "" {
+		return BGPSpeaker{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return BGPSpeaker{}, err
+	}
+
+	var r SingleBGPSpeaker
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgp-speakers/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return BGPSpeaker{}, err
+	}
+	return r.BGPSpeaker, nil
+}
+
+func (c *Client) CreateBGPSpeaker(s BGPSpeaker) (BGPSpeaker, error) {
+	if s.LocalAS <= 0 {
+		return BGPSpeaker{}, fmt.Errorf("missing local AS number")
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return BGPSpeaker{}, err
+	}
+
+	var r SingleBGPSpeaker
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/bgp-speakers", c.URL), SingleBGPSpeaker{BGPSpeaker: s}, http.StatusCreated, &r)
+	if err != nil {
+		return BGPSpeaker{}, err
+	}
+	return r.BGPSpeaker, nil
+}
+
+func (c *Client) UpdateBGPSpeaker(id string, u BGPSpeakerUpdate) (BGPSpeaker, error) {
+	if id == "" {
+		return BGPSpeaker{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return BGPSpeaker{}, err
+	}
+
+	var r SingleBGPSpeaker
+	body := map[string]BGPSpeakerUpdate{"bgp_speaker": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/bgp-speakers/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return BGPSpeaker{}, err
+	}
+	return r.BGPSpeaker, nil
+}
+
+func (c *Client) DeleteBGPSpeaker(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/bgp-speakers/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) BGPPeers(opts ...ListOpts) ([]BGPPeer, error) {
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetBGPPeers
+	err := c.send(http.MethodGet, c.listURL("bgp-peers", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.BGPPeers, nil
+}
+
+func (c *Client) BGPPeer(id string) (BGPPeer, error) {
+	if id == "" {
+		return BGPPeer{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return BGPPeer{}, err
+	}
+
+	var r SingleBGPPeer
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgp-peers/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return BGPPeer{}, err
+	}
+	return r.BGPPeer, nil
+}
+
+func (c *Client) CreateBGPPeer(p BGPPeer) (BGPPeer, error) {
+	if p.PeerIP == "" {
+		return BGPPeer{}, fmt.Errorf("missing peer IP address")
+	}
+	if p.RemoteAS <= 0 {
+		return BGPPeer{}, fmt.Errorf("missing remote AS number")
+	}
+	if p.AuthType == "" {
+		p.AuthType = "none"
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return BGPPeer{}, err
+	}
+
+	var r SingleBGPPeer
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/bgp-peers", c.URL), SingleBGPPeer{BGPPeer: p}, http.StatusCreated, &r)
+	if err != nil {
+		return BGPPeer{}, err
+	}
+	return r.BGPPeer, nil
+}
+
+func (c *Client) UpdateBGPPeer(id string, u BGPPeerUpdate) (BGPPeer, error) {
+	if id == "" {
+		return BGPPeer{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return BGPPeer{}, err
+	}
+
+	var r SingleBGPPeer
+	body := map[string]BGPPeerUpdate{"bgp_peer": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/bgp-peers/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return BGPPeer{}, err
+	}
+	return r.BGPPeer, nil
+}
+
+func (c *Client) DeleteBGPPeer(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/bgp-peers/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+// speakerAction calls one of the PUT actions of a BGP speaker, such as
+// add_bgp_peer, with a body holding a single id.
+func (c *Client) speakerAction(speakerID, action, key, id string) error {
+	if speakerID == "" {
+		return fmt.Errorf("empty 'speakerID' parameter")
+	}
+	if id == "" {
+		return fmt.Errorf("empty '%s' parameter", key)
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return err
+	}
+
+	body := map[string]string{key: id}
+	return c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/bgp-speakers/%s/%s", c.URL, speakerID, action), body, http.StatusOK, nil)
+}
+
+func (c *Client) AddBGPPeer(speakerID, peerID string) error {
+	return c.speakerAction(speakerID, "add_bgp_peer", "bgp_peer_id", peerID)
+}
+
+func (c *Client) RemoveBGPPeer(speakerID, peerID string) error {
+	return c.speakerAction(speakerID, "remove_bgp_peer", "bgp_peer_id", peerID)
+}
+
+// AddGatewayNetwork makes the speaker advertise the tenant networks and
+// floating IPs routed through the given external network.
+func (c *Client) AddGatewayNetwork(speakerID, networkID string) error {
+	return c.speakerAction(speakerID, "add_gateway_network", "network_id", networkID)
+}
+
+func (c *Client) RemoveGatewayNetwork(speakerID, networkID string) error {
+	return c.speakerAction(speakerID, "remove_gateway_network", "network_id", networkID)
+}
+
+func (c *Client) AdvertisedRoutes(speakerID string) ([]AdvertisedRoute, error) {
+	if speakerID == "" {
+		return nil, fmt.Errorf("empty 'speakerID' parameter")
+	}
+	if err := c.requireExtension(bgpExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetAdvertisedRoutes
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgp-speakers/%s/get_advertised_routes", c.URL, speakerID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.AdvertisedRoutes, nil
+}
+
+func (c *Client) BGPAgentSpeakers(agentID string) ([]BGPSpeaker, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("empty 'agentID' parameter")
+	}
+	if err := c.requireExtension(bgpSchedulerExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetBGPSpeakers
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/agents/%s/bgp-drinstances", c.URL, agentID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.BGPSpeakers, nil
+}
+
+func (c *Client) AddBGPSpeakerToAgent(agentID, speakerID string) error {
+	if agentID == "" {
+		return fmt.Errorf("empty 'agentID' parameter")
+	}
+	if speakerID == "" {
+		return fmt.Errorf("empty 'speakerID' parameter")
+	}
+	if err := c.requireExtension(bgpSchedulerExtension); err != nil {
+		return err
+	}
+
+	body := map[string]string{"bgp_speaker_id": speakerID}
+	return c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/agents/%s/bgp-drinstances", c.URL, agentID), body, http.StatusCreated, nil)
+}
+
+func (c *Client) RemoveBGPSpeakerFromAgent(agentID, speakerID string) error {
+	if agentID == "" {
+		return fmt.Errorf("empty 'agentID' parameter")
+	}
+	if speakerID == "" {
+		return fmt.Errorf("empty 'speakerID' parameter")
+	}
+	if err := c.requireExtension(bgpSchedulerExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/agents/%s/bgp-drinstances/%s", c.URL, agentID, speakerID), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) BGPSpeakerAgents(speakerID string) ([]Agent, error) {
+	if speakerID == "" {
+		return nil, fmt.Errorf("empty 'speakerID' parameter")
+	}
+	if err := c.requireExtension(bgpSchedulerExtension); err != nil {
+		return nil, err
+	}
+	return c.getAgents(fmt.Sprintf("%s/v2.0/bgp-speakers/%s/bgp-dragents", c.URL, speakerID))
+}
@@ -0,0 +1,342 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const advertisedRoutesResp = `{
+  "advertised_routes": [
+    {"destination": "10.0.0.0/24", "next_hop": "172.24.4.10"},
+    {"destination": "172.24.4.20/32", "next_hop": "172.24.4.10"}
+  ]
+}`
+
+const bgpSpeakersResp = `{
+  "bgp_speakers": [
+    {
+      "id": "5f227f14-4f46-4eca-9524-fc5a1eabc358",
+      "name": "bgp-speaker-1",
+      "local_as": 64512,
+      "ip_version": 4,
+      "advertise_floating_ip_host_routes": true,
+      "advertise_tenant_networks": true,
+      "peers": ["a7193581-a31c-4ea5-8218-b3052758461f"],
+      "networks": ["f2269b61-6755-4174-8f64-5e3a7fda1ab4"],
+      "project_id": "b7ae4b2e3e6f4df0a4bd2ab41dc8d7f6"
+    }
+  ]
+}`
+
+const bgpSpeakerResp = `{
+  "bgp_speaker": {
+    "id": "5f227f14-4f46-4eca-9524-fc5a1eabc358",
+    "name": "bgp-speaker-1",
+    "local_as": 64512,
+    "ip_version": 4,
+    "advertise_floating_ip_host_routes": false,
+    "advertise_tenant_networks": true,
+    "peers": [],
+    "networks": []
+  }
+}`
+
+const bgpPeersResp = `{
+  "bgp_peers": [
+    {
+      "id": "a7193581-a31c-4ea5-8218-b3052758461f",
+      "name": "bgp-peer-1",
+      "peer_ip": "192.168.1.1",
+      "remote_as": 64513,
+      "auth_type": "md5"
+    }
+  ]
+}`
+
+const bgpPeerResp = `{
+  "bgp_peer": {
+    "id": "a7193581-a31c-4ea5-8218-b3052758461f",
+    "name": "bgp-peer-1",
+    "peer_ip": "192.168.1.1",
+    "remote_as": 64513,
+    "auth_type": "none"
+  }
+}`
+
+const bgpDRAgentsResp = `{
+  "agents": [
+    {
+      "id": "4ae2f2a6-2d6f-4bc0-9a2e-7f5b2e0e3e10",
+      "agent_type": "BGP dynamic routing agent",
+      "binary": "neutron-bgp-dragent",
+      "host": "network1",
+      "alive": true
+    }
+  ]
+}`
+
+var _ = Describe("BGP dynamic routing", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		body   []byte
+		query  string
+	)
+
+	BeforeEach(func() {
+		method, path, query, body = "", "", "", nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			method = r.Method
+			path = r.URL.Path
+			query = r.URL.RawQuery
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body)
+			case r.Method == http.MethodPut:
+				w.Write(body)
+			case r.URL.Path == "/v2.0/bgp-speakers", r.URL.Path == "/v2.0/agents/agent1/bgp-drinstances":
+				fmt.Fprintln(w, bgpSpeakersResp)
+			case r.URL.Path == "/v2.0/bgp-speakers/speaker1":
+				fmt.Fprintln(w, bgpSpeakerResp)
+			case r.URL.Path == "/v2.0/bgp-peers":
+				fmt.Fprintln(w, bgpPeersResp)
+			case r.URL.Path == "/v2.0/bgp-peers/peer1":
+				fmt.Fprintln(w, bgpPeerResp)
+			case r.URL.Path == "/v2.0/bgp-speakers/speaker1/bgp-dragents":
+				fmt.Fprintln(w, bgpDRAgentsResp)
+			default:
+				fmt.Fprintln(w, advertisedRoutesResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("creates a BGP peer with no authentication by default", func() {
+		p, err := client.CreateBGPPeer(neutron.BGPPeer{Name: "tor1", PeerIP: "172.24.4.1", RemoteAS: 64513})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/bgp-peers"))
+		Expect(body).To(MatchJSON(`{
+			"bgp_peer": {"name": "tor1", "peer_ip": "172.24.4.1", "remote_as": 64513, "auth_type": "none"}
+		}`))
+		Expect(p.RemoteAS).To(Equal(64513))
+	})
+
+	It("adds a peer to a speaker", func() {
+		err := client.AddBGPPeer("speaker1", "peer1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/bgp-speakers/speaker1/add_bgp_peer"))
+		Expect(body).To(MatchJSON(`{"bgp_peer_id": "peer1"}`))
+	})
+
+	It("adds a gateway network to a speaker", func() {
+		err := client.AddGatewayNetwork("speaker1", "public")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/bgp-speakers/speaker1/add_gateway_network"))
+		Expect(body).To(MatchJSON(`{"network_id": "public"}`))
+	})
+
+	It("lists advertised routes", func() {
+		routes, err := client.AdvertisedRoutes("speaker1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/bgp-speakers/speaker1/get_advertised_routes"))
+		Expect(routes).To(ContainElement(neutron.AdvertisedRoute{Destination: "10.0.0.0/24", NextHop: "172.24.4.10"}))
+	})
+
+	It("schedules a speaker on an agent", func() {
+		err := client.AddBGPSpeakerToAgent("agent1", "speaker1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(path).To(Equal("/v2.0/agents/agent1/bgp-drinstances"))
+		Expect(body).To(MatchJSON(`{"bgp_speaker_id": "speaker1"}`))
+
+		err = client.RemoveBGPSpeakerFromAgent("agent1", "speaker1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/agents/agent1/bgp-drinstances/speaker1"))
+	})
+
+	Describe("BGP speakers", func() {
+		It("lists BGP speakers", func() {
+			speakers, err := client.BGPSpeakers(neutron.ListOpts{ProjectID: "b7ae4b2e3e6f4df0a4bd2ab41dc8d7f6"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgp-speakers"))
+			Expect(query).To(Equal("project_id=b7ae4b2e3e6f4df0a4bd2ab41dc8d7f6"))
+			Expect(speakers).To(HaveLen(1))
+			Expect(speakers[0].LocalAS).To(Equal(64512))
+			Expect(*speakers[0].AdvertiseTenantNetworks).To(BeTrue())
+			Expect(speakers[0].Peers).To(Equal([]string{"a7193581-a31c-4ea5-8218-b3052758461f"}))
+			Expect(speakers[0].Networks).To(Equal([]string{"f2269b61-6755-4174-8f64-5e3a7fda1ab4"}))
+		})
+
+		It("gets a BGP speaker", func() {
+			s, err := client.BGPSpeaker("speaker1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.Name).To(Equal("bgp-speaker-1"))
+			Expect(*s.AdvertiseFloatingIPHostRoutes).To(BeFalse())
+		})
+
+		It("creates a BGP speaker", func() {
+			disabled := false
+			s, err := client.CreateBGPSpeaker(neutron.BGPSpeaker{
+				Name:                          "speaker1",
+				LocalAS:                       64512,
+				IPVersion:                     4,
+				AdvertiseFloatingIPHostRoutes: &disabled,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(path).To(Equal("/v2.0/bgp-speakers"))
+			Expect(body).To(MatchJSON(`{
+				"bgp_speaker": {"name": "speaker1", "local_as": 64512, "ip_version": 4, "advertise_floating_ip_host_routes": false}
+			}`))
+			Expect(s.LocalAS).To(Equal(64512))
+		})
+
+		It("requires a local AS number", func() {
+			_, err := client.CreateBGPSpeaker(neutron.BGPSpeaker{Name: "speaker1"})
+			Expect(err).To(MatchError("missing local AS number"))
+			Expect(method).To(BeEmpty())
+		})
+
+		It("updates a BGP speaker", func() {
+			enabled := true
+			_, err := client.UpdateBGPSpeaker("speaker1", neutron.BGPSpeakerUpdate{AdvertiseTenantNetworks: &enabled})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/bgp-speakers/speaker1"))
+			Expect(body).To(MatchJSON(`{"bgp_speaker": {"advertise_tenant_networks": true}}`))
+		})
+
+		It("deletes a BGP speaker", func() {
+			err := client.DeleteBGPSpeaker("speaker1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/bgp-speakers/speaker1"))
+		})
+
+		It("removes a peer and a gateway network from a speaker", func() {
+			err := client.RemoveBGPPeer("speaker1", "peer1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgp-speakers/speaker1/remove_bgp_peer"))
+			Expect(body).To(MatchJSON(`{"bgp_peer_id": "peer1"}`))
+
+			err = client.RemoveGatewayNetwork("speaker1", "public")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/bgp-speakers/speaker1/remove_gateway_network"))
+			Expect(body).To(MatchJSON(`{"network_id": "public"}`))
+		})
+
+		It("requires the ids of speaker actions", func() {
+			err := client.AddBGPPeer("speaker1", "")
+			Expect(err).To(MatchError("empty 'bgp_peer_id' parameter"))
+			err = client.AddGatewayNetwork("", "public")
+			Expect(err).To(MatchError("empty 'speakerID' parameter"))
+			Expect(method).To(BeEmpty())
+		})
+	})
+
+	Describe("BGP peers", func() {
+		It("lists BGP peers", func() {
+			peers, err := client.BGPPeers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgp-peers"))
+			Expect(peers).To(Equal([]neutron.BGPPeer{{
+				ID:       "a7193581-a31c-4ea5-8218-b3052758461f",
+				Name:     "bgp-peer-1",
+				PeerIP:   "192.168.1.1",
+				RemoteAS: 64513,
+				AuthType: "md5",
+			}}))
+		})
+
+		It("gets a BGP peer", func() {
+			p, err := client.BGPPeer("peer1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(p.PeerIP).To(Equal("192.168.1.1"))
+			Expect(p.AuthType).To(Equal("none"))
+		})
+
+		It("keeps the given authentication type", func() {
+			_, err := client.CreateBGPPeer(neutron.BGPPeer{PeerIP: "192.168.1.1", RemoteAS: 64513, AuthType: "md5", Password: "secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"bgp_peer": {"peer_ip": "192.168.1.1", "remote_as": 64513, "auth_type": "md5", "password": "secret"}
+			}`))
+		})
+
+		It("validates the peer before sending", func() {
+			_, err := client.CreateBGPPeer(neutron.BGPPeer{RemoteAS: 64513})
+			Expect(err).To(MatchError("missing peer IP address"))
+			_, err = client.CreateBGPPeer(neutron.BGPPeer{PeerIP: "192.168.1.1"})
+			Expect(err).To(MatchError("missing remote AS number"))
+			Expect(method).To(BeEmpty())
+		})
+
+		It("updates a BGP peer", func() {
+			password := "new-secret"
+			_, err := client.UpdateBGPPeer("peer1", neutron.BGPPeerUpdate{Password: &password})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/bgp-peers/peer1"))
+			Expect(body).To(MatchJSON(`{"bgp_peer": {"password": "new-secret"}}`))
+		})
+
+		It("deletes a BGP peer", func() {
+			err := client.DeleteBGPPeer("peer1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/bgp-peers/peer1"))
+		})
+	})
+
+	Describe("dynamic routing agents", func() {
+		It("lists the speakers hosted by an agent", func() {
+			speakers, err := client.BGPAgentSpeakers("agent1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodGet))
+			Expect(path).To(Equal("/v2.0/agents/agent1/bgp-drinstances"))
+			Expect(speakers[0].ID).To(Equal("5f227f14-4f46-4eca-9524-fc5a1eabc358"))
+		})
+
+		It("lists the agents hosting a speaker", func() {
+			agents, err := client.BGPSpeakerAgents("speaker1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgp-speakers/speaker1/bgp-dragents"))
+			Expect(agents).To(HaveLen(1))
+			Expect(agents[0].AgentType).To(Equal(neutron.BGPDRAgentType))
+			Expect(agents[0].Host).To(Equal("network1"))
+		})
+
+		It("requires agent and speaker ids", func() {
+			err := client.AddBGPSpeakerToAgent("", "speaker1")
+			Expect(err).To(MatchError("empty 'agentID' parameter"))
+			err = client.RemoveBGPSpeakerFromAgent("agent1", "")
+			Expect(err).To(MatchError("empty 'speakerID' parameter"))
+			Expect(method).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,446 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	BGPVPNTypeL2 = "l2"
+	BGPVPNTypeL3 = "l3"
+)
+
+// BGPVPN interconnects Neutron networks, routers and ports with a BGP VPN of
+// the datacenter fabric. Route targets and distinguishers use the
+// "ASN:number" or "IP:number" forms.
+type BGPVPN struct {
+	ID                  string   `json:"id,omitempty"`
+	Name                string   `json:"name,omitempty"`
+	Type                string   `json:"type,omitempty"`
+	RouteTargets        []string `json:"route_targets,omitempty"`
+	ImportTargets       []string `json:"import_targets,omitempty"`
+	ExportTargets       []string `json:"export_targets,omitempty"`
+	RouteDistinguishers []string `json:"route_distinguishers,omitempty"`
+	VNI                 int      `json:"vni,omitempty"`
+	LocalPref           int      `json:"local_pref,omitempty"`
+	Networks            []string `json:"networks,omitempty"`
+	Routers             []string `json:"routers,omitempty"`
+	Ports               []string `json:"ports,omitempty"`
+	TenantID            string   `json:"tenant_id,omitempty"`
+	ProjectID           string   `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetBGPVPNs struct {
+	BGPVPNs []BGPVPN `json:"bgpvpns"`
+}
+
+type SingleBGPVPN struct {
+	BGPVPN BGPVPN `json:"bgpvpn"`
+}
+
+// BGPVPNUpdate holds the BGP VPN attributes to change; nil fields are left as
+// is.
+type BGPVPNUpdate struct {
+	Name                *string   `json:"name,omitempty"`
+	RouteTargets        *[]string `json:"route_targets,omitempty"`
+	ImportTargets       *[]string `json:"import_targets,omitempty"`
+	ExportTargets       *[]string `json:"export_targets,omitempty"`
+	RouteDistinguishers *[]string `json:"route_distinguishers,omitempty"`
+	VNI                 *int      `json:"vni,omitempty"`
+	LocalPref           *int      `json:"local_pref,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type BGPVPNNetworkAssociation struct {
+	ID        string `json:"id,omitempty"`
+	NetworkID string `json:"network_id"`
+	TenantID  string `json:"tenant_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetBGPVPNNetworkAssociations struct {
+	BGPVPNNetworkAssociations []BGPVPNNetworkAssociation `json:"network_associations"`
+}
+
+type SingleBGPVPNNetworkAssociation struct {
+	BGPVPNNetworkAssociation BGPVPNNetworkAssociation `json:"network_association"`
+}
+
+type BGPVPNRouterAssociation struct {
+	ID                   string `json:"id,omitempty"`
+	RouterID             string `json:"router_id"`
+	AdvertiseExtraRoutes *bool  `json:"advertise_extra_routes,omitempty"`
+	TenantID             string `json:"tenant_id,omitempty"`
+	ProjectID            string `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetBGPVPNRouterAssociations struct {
+	BGPVPNRouterAssociations []BGPVPNRouterAssociation `json:"router_associations"`
+}
+
+type SingleBGPVPNRouterAssociation struct {
+	BGPVPNRouterAssociation BGPVPNRouterAssociation `json:"router_association"`
+}
+
+// BGPVPNRouterAssociationUpdate holds the router association attributes to
+// change; nil fields are left as is.
+type BGPVPNRouterAssociationUpdate struct {
+	AdvertiseExtraRoutes *bool `json:"advertise_extra_routes,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// BGPVPNPortRoute is a route advertised for a port association. Type is
+// "prefix", with Prefix set, or "bgpvpn", with BGPVPNID set to leak the
+// routes of another BGP VPN.
+type BGPVPNPortRoute struct {
+	Type      string `json:"type"`
+	Prefix    string `json:"prefix,omitempty"`
+	BGPVPNID  string `json:"bgpvpn_id,omitempty"`
+	LocalPref int    `json:"local_pref,omitempty"`
+}
+
+type BGPVPNPortAssociation struct {
+	ID                string            `json:"id,omitempty"`
+	PortID            string            `json:"port_id"`
+	Routes            []BGPVPNPortRoute `json:"routes,omitempty"`
+	AdvertiseFixedIPs *bool             `json:"advertise_fixed_ips,omitempty"`
+	TenantID          string            `json:"tenant_id,omitempty"`
+	ProjectID         string            `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetBGPVPNPortAssociations struct {
+	BGPVPNPortAssociations []BGPVPNPortAssociation `json:"port_associations"`
+}
+
+type SingleBGPVPNPortAssociation struct {
+	BGPVPNPortAssociation BGPVPNPortAssociation `json:"port_association"`
+}
+
+// BGPVPNPortAssociationUpdate holds the port association attributes to
+// change; nil fields are left as is.
+type BGPVPNPortAssociationUpdate struct {
+	Routes            *[]BGPVPNPortRoute `json:"routes,omitempty"`
+	AdvertiseFixedIPs *bool              `json:"advertise_fixed_ips,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+const bgpvpnExtension = "bgpvpn"
+
+func (c *Client) BGPVPNs(opts ...ListOpts) ([]BGPVPN, error) {
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetBGPVPNs
+	err := c.send(http.MethodGet, c.listURL("bgpvpn/bgpvpns", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.BGPVPNs, nil
+}
+
+func (c *Client) BGPVPN(id string) (BGPVPN, error) {
+	if id == "" {
+		return BGPVPN{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPN{}, err
+	}
+
+	var r SingleBGPVPN
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return BGPVPN{}, err
+	}
+	return r.BGPVPN, nil
+}
+
+func (c *Client) CreateBGPVPN(v BGPVPN) (BGPVPN, error) {
+	if v.Type != "" && v.Type != BGPVPNTypeL2 && v.Type != BGPVPNTypeL3 {
+		return BGPVPN{}, fmt.Errorf("invalid BGP VPN type '%s'", v.Type)
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPN{}, err
+	}
+
+	var r SingleBGPVPN
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns", c.URL), SingleBGPVPN{BGPVPN: v}, http.StatusCreated, &r)
+	if err != nil {
+		return BGPVPN{}, err
+	}
+	return r.BGPVPN, nil
+}
+
+func (c *Client) UpdateBGPVPN(id string, u BGPVPNUpdate) (BGPVPN, error) {
+	if id == "" {
+		return BGPVPN{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPN{}, err
+	}
+
+	var r SingleBGPVPN
+	body := map[string]BGPVPNUpdate{"bgpvpn": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return BGPVPN{}, err
+	}
+	return r.BGPVPN, nil
+}
+
+func (c *Client) DeleteBGPVPN(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) BGPVPNNetworkAssociations(bgpvpnID string) ([]BGPVPNNetworkAssociation, error) {
+	if bgpvpnID == "" {
+		return nil, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetBGPVPNNetworkAssociations
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/network_associations", c.URL, bgpvpnID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.BGPVPNNetworkAssociations, nil
+}
+
+func (c *Client) BGPVPNNetworkAssociation(bgpvpnID, id string) (BGPVPNNetworkAssociation, error) {
+	if bgpvpnID == "" {
+		return BGPVPNNetworkAssociation{}, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if id == "" {
+		return BGPVPNNetworkAssociation{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPNNetworkAssociation{}, err
+	}
+
+	var r SingleBGPVPNNetworkAssociation
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/network_associations/%s", c.URL, bgpvpnID, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return BGPVPNNetworkAssociation{}, err
+	}
+	return r.BGPVPNNetworkAssociation, nil
+}
+
+func (c *Client) CreateBGPVPNNetworkAssociation(bgpvpnID string, a BGPVPNNetworkAssociation) (BGPVPNNetworkAssociation, error) {
+	if bgpvpnID == "" {
+		return BGPVPNNetworkAssociation{}, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPNNetworkAssociation{}, err
+	}
+
+	var r SingleBGPVPNNetworkAssociation
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/network_associations", c.URL, bgpvpnID), SingleBGPVPNNetworkAssociation{BGPVPNNetworkAssociation: a}, http.StatusCreated, &r)
+	if err != nil {
+		return BGPVPNNetworkAssociation{}, err
+	}
+	return r.BGPVPNNetworkAssociation, nil
+}
+
+func (c *Client) DeleteBGPVPNNetworkAssociation(bgpvpnID, id string) error {
+	if bgpvpnID == "" {
+		return fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/network_associations/%s", c.URL, bgpvpnID, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) BGPVPNRouterAssociations(bgpvpnID string) ([]BGPVPNRouterAssociation, error) {
+	if bgpvpnID == "" {
+		return nil, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetBGPVPNRouterAssociations
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/router_associations", c.URL, bgpvpnID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.BGPVPNRouterAssociations, nil
+}
+
+func (c *Client) BGPVPNRouterAssociation(bgpvpnID, id string) (BGPVPNRouterAssociation, error) {
+	if bgpvpnID == "" {
+		return BGPVPNRouterAssociation{}, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if id == "" {
+		return BGPVPNRouterAssociation{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPNRouterAssociation{}, err
+	}
+
+	var r SingleBGPVPNRouterAssociation
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/router_associations/%s", c.URL, bgpvpnID, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return BGPVPNRouterAssociation{}, err
+	}
+	return r.BGPVPNRouterAssociation, nil
+}
+
+func (c *Client) CreateBGPVPNRouterAssociation(bgpvpnID string, a BGPVPNRouterAssociation) (BGPVPNRouterAssociation, error) {
+	if bgpvpnID == "" {
+		return BGPVPNRouterAssociation{}, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPNRouterAssociation{}, err
+	}
+
+	var r SingleBGPVPNRouterAssociation
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/router_associations", c.URL, bgpvpnID), SingleBGPVPNRouterAssociation{BGPVPNRouterAssociation: a}, http.StatusCreated, &r)
+	if err != nil {
+		return BGPVPNRouterAssociation{}, err
+	}
+	return r.BGPVPNRouterAssociation, nil
+}
+
+func (c *Client) UpdateBGPVPNRouterAssociation(bgpvpnID, id string, u BGPVPNRouterAssociationUpdate) (BGPVPNRouterAssociation, error) {
+	if bgpvpnID == "" {
+		return BGPVPNRouterAssociation{}, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if id == "" {
+		return BGPVPNRouterAssociation{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPNRouterAssociation{}, err
+	}
+
+	var r SingleBGPVPNRouterAssociation
+	body := map[string]BGPVPNRouterAssociationUpdate{"router_association": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/router_associations/%s", c.URL, bgpvpnID, id), body, http.StatusOK, &r)
+	if err != nil {
+		return BGPVPNRouterAssociation{}, err
+	}
+	return r.BGPVPNRouterAssociation, nil
+}
+
+func (c *Client) DeleteBGPVPNRouterAssociation(bgpvpnID, id string) error {
+	if bgpvpnID == "" {
+		return fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/router_associations/%s", c.URL, bgpvpnID, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) BGPVPNPortAssociations(bgpvpnID string) ([]BGPVPNPortAssociation, error) {
+	if bgpvpnID == "" {
+		return nil, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetBGPVPNPortAssociations
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/port_associations", c.URL, bgpvpnID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.BGPVPNPortAssociations, nil
+}
+
+func (c *Client) BGPVPNPortAssociation(bgpvpnID, id string) (BGPVPNPortAssociation, error) {
+	if bgpvpnID == "" {
+		return BGPVPNPortAssociation{}, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if id == "" {
+		return BGPVPNPortAssociation{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPNPortAssociation{}, err
+	}
+
+	var r SingleBGPVPNPortAssociation
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/port_associations/%s", c.URL, bgpvpnID, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return BGPVPNPortAssociation{}, err
+	}
+	return r.BGPVPNPortAssociation, nil
+}
+
+func (c *Client) CreateBGPVPNPortAssociation(bgpvpnID string, a BGPVPNPortAssociation) (BGPVPNPortAssociation, error) {
+	if bgpvpnID == "" {
+		return BGPVPNPortAssociation{}, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPNPortAssociation{}, err
+	}
+
+	var r SingleBGPVPNPortAssociation
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/port_associations", c.URL, bgpvpnID), SingleBGPVPNPortAssociation{BGPVPNPortAssociation: a}, http.StatusCreated, &r)
+	if err != nil {
+		return BGPVPNPortAssociation{}, err
+	}
+	return r.BGPVPNPortAssociation, nil
+}
+
+func (c *Client) UpdateBGPVPNPortAssociation(bgpvpnID, id string, u BGPVPNPortAssociationUpdate) (BGPVPNPortAssociation, error) {
+	if bgpvpnID == "" {
+		return BGPVPNPortAssociation{}, fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if id == "" {
+		return BGPVPNPortAssociation{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return BGPVPNPortAssociation{}, err
+	}
+
+	var r SingleBGPVPNPortAssociation
+	body := map[string]BGPVPNPortAssociationUpdate{"port_association": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/port_associations/%s", c.URL, bgpvpnID, id), body, http.StatusOK, &r)
+	if err != nil {
+		return BGPVPNPortAssociation{}, err
+	}
+	return r.BGPVPNPortAssociation, nil
+}
+
+func (c *Client) DeleteBGPVPNPortAssociation(bgpvpnID, id string) error {
+	if bgpvpnID == "" {
+		return fmt.Errorf("empty 'bgpvpnID' parameter")
+	}
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(bgpvpnExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/bgpvpn/bgpvpns/%s/port_associations/%s", c.URL, bgpvpnID, id), nil, http.StatusNoContent, nil)
+}
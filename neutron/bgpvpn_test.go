@@ -0,0 +1,368 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const bgpvpnResp = `{
+  "bgpvpn": {
+    "id": "460ac411-3dfb-45bb-8116-ed1a7233d143",
+    "name": "fabric",
+    "type": "l3",
+    "route_targets": ["64512:1444"],
+    "import_targets": [],
+    "export_targets": [],
+    "route_distinguishers": [],
+    "networks": ["a4f2b8df-cb42-4893-a333-d0b5c36ade17"],
+    "routers": [],
+    "ports": [],
+    "local_pref": null,
+    "vni": 1000
+  }
+}`
+
+const portAssociationResp = `{
+  "port_association": {
+    "id": "8e4a5f9c-35d5-4fb8-a5fa-3b82e4c76dd8",
+    "port_id": "5e8f1e2d-0c6f-4a5c-8e1b-0d7a5e7b8f4c",
+    "advertise_fixed_ips": false,
+    "routes": [
+      {"type": "prefix", "prefix": "203.0.113.0/24", "local_pref": 100}
+    ]
+  }
+}`
+
+const bgpvpnsResp = `{
+  "bgpvpns": [
+    {
+      "id": "460ac411-3dfb-45bb-8116-ed1a7233d143",
+      "name": "fabric",
+      "type": "l3",
+      "route_targets": ["64512:1444"],
+      "project_id": "b7ae4b2e3e6f4df0a4bd2ab41dc8d7f6"
+    },
+    {
+      "id": "2dd0d5e6-3c2b-4a8e-b8f7-51d2c2a7a0e2",
+      "name": "campus",
+      "type": "l2",
+      "route_distinguishers": ["64512:2001"],
+      "project_id": "b7ae4b2e3e6f4df0a4bd2ab41dc8d7f6"
+    }
+  ]
+}`
+
+const networkAssociationsResp = `{
+  "network_associations": [
+    {"id": "73238ca1-e05d-4c7a-b4d4-70407b4b8730", "network_id": "a4f2b8df-cb42-4893-a333-d0b5c36ade17"}
+  ]
+}`
+
+const networkAssociationResp = `{
+  "network_association": {"id": "73238ca1-e05d-4c7a-b4d4-70407b4b8730", "network_id": "a4f2b8df-cb42-4893-a333-d0b5c36ade17"}
+}`
+
+const routerAssociationsResp = `{
+  "router_associations": [
+    {"id": "95cbd6c1-7b8e-4f0f-9d5c-3f6c1b8f3c1d", "router_id": "b1b3b0b5-5b8a-4c7c-8a86-3b6bd7c7f1f0", "advertise_extra_routes": true}
+  ]
+}`
+
+const routerAssociationResp = `{
+  "router_association": {"id": "95cbd6c1-7b8e-4f0f-9d5c-3f6c1b8f3c1d", "router_id": "b1b3b0b5-5b8a-4c7c-8a86-3b6bd7c7f1f0", "advertise_extra_routes": false}
+}`
+
+const portAssociationsResp = `{
+  "port_associations": [
+    {
+      "id": "8e4a5f9c-35d5-4fb8-a5fa-3b82e4c76dd8",
+      "port_id": "5e8f1e2d-0c6f-4a5c-8e1b-0d7a5e7b8f4c",
+      "routes": [
+        {"type": "bgpvpn", "bgpvpn_id": "2dd0d5e6-3c2b-4a8e-b8f7-51d2c2a7a0e2"}
+      ]
+    }
+  ]
+}`
+
+var _ = Describe("BGP VPN", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		method, path, body = "", "", nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			method = r.Method
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/port_associations"):
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, portAssociationResp)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body)
+			case r.Method == http.MethodPut:
+				w.Write(body)
+			case strings.HasSuffix(r.URL.Path, "/bgpvpns"):
+				fmt.Fprintln(w, bgpvpnsResp)
+			case strings.HasSuffix(r.URL.Path, "/network_associations"):
+				fmt.Fprintln(w, networkAssociationsResp)
+			case strings.Contains(r.URL.Path, "/network_associations/"):
+				fmt.Fprintln(w, networkAssociationResp)
+			case strings.HasSuffix(r.URL.Path, "/router_associations"):
+				fmt.Fprintln(w, routerAssociationsResp)
+			case strings.Contains(r.URL.Path, "/router_associations/"):
+				fmt.Fprintln(w, routerAssociationResp)
+			case strings.HasSuffix(r.URL.Path, "/port_associations"):
+				fmt.Fprintln(w, portAssociationsResp)
+			case strings.Contains(r.URL.Path, "/port_associations/"):
+				fmt.Fprintln(w, portAssociationResp)
+			default:
+				fmt.Fprintln(w, bgpvpnResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("gets a BGP VPN", func() {
+		v, err := client.BGPVPN("460ac411-3dfb-45bb-8116-ed1a7233d143")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143"))
+		Expect(v.Type).To(Equal(neutron.BGPVPNTypeL3))
+		Expect(v.RouteTargets).To(Equal([]string{"64512:1444"}))
+		Expect(v.VNI).To(Equal(1000))
+	})
+
+	It("rejects unknown BGP VPN types", func() {
+		_, err := client.CreateBGPVPN(neutron.BGPVPN{Type: "l4"})
+		Expect(err).To(MatchError("invalid BGP VPN type 'l4'"))
+	})
+
+	It("associates a port with prefix routes", func() {
+		advertise := false
+		a, err := client.CreateBGPVPNPortAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", neutron.BGPVPNPortAssociation{
+			PortID:            "5e8f1e2d-0c6f-4a5c-8e1b-0d7a5e7b8f4c",
+			AdvertiseFixedIPs: &advertise,
+			Routes: []neutron.BGPVPNPortRoute{
+				{Type: "prefix", Prefix: "203.0.113.0/24", LocalPref: 100},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/port_associations"))
+		Expect(body).To(MatchJSON(`{
+			"port_association": {
+				"port_id": "5e8f1e2d-0c6f-4a5c-8e1b-0d7a5e7b8f4c",
+				"advertise_fixed_ips": false,
+				"routes": [{"type": "prefix", "prefix": "203.0.113.0/24", "local_pref": 100}]
+			}
+		}`))
+		Expect(a.ID).To(Equal("8e4a5f9c-35d5-4fb8-a5fa-3b82e4c76dd8"))
+		Expect(a.Routes).To(HaveLen(1))
+	})
+
+	It("removes a network association", func() {
+		err := client.DeleteBGPVPNNetworkAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", "assoc1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/network_associations/assoc1"))
+	})
+
+	Describe("BGP VPNs", func() {
+		It("lists BGP VPNs", func() {
+			vpns, err := client.BGPVPNs(neutron.ListOpts{ProjectID: "b7ae4b2e3e6f4df0a4bd2ab41dc8d7f6"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns"))
+			Expect(vpns).To(HaveLen(2))
+			Expect(vpns[0].Name).To(Equal("fabric"))
+			Expect(vpns[1].Type).To(Equal(neutron.BGPVPNTypeL2))
+			Expect(vpns[1].RouteDistinguishers).To(Equal([]string{"64512:2001"}))
+		})
+
+		It("creates a BGP VPN", func() {
+			v, err := client.CreateBGPVPN(neutron.BGPVPN{
+				Name:         "fabric",
+				Type:         neutron.BGPVPNTypeL3,
+				RouteTargets: []string{"64512:1444"},
+				VNI:          1000,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns"))
+			Expect(body).To(MatchJSON(`{
+				"bgpvpn": {"name": "fabric", "type": "l3", "route_targets": ["64512:1444"], "vni": 1000}
+			}`))
+			Expect(v.VNI).To(Equal(1000))
+		})
+
+		It("updates a BGP VPN", func() {
+			targets := []string{}
+			pref := 200
+			_, err := client.UpdateBGPVPN("460ac411-3dfb-45bb-8116-ed1a7233d143", neutron.BGPVPNUpdate{
+				ImportTargets: &targets,
+				LocalPref:     &pref,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143"))
+			Expect(body).To(MatchJSON(`{"bgpvpn": {"import_targets": [], "local_pref": 200}}`))
+		})
+
+		It("deletes a BGP VPN", func() {
+			err := client.DeleteBGPVPN("460ac411-3dfb-45bb-8116-ed1a7233d143")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143"))
+		})
+
+		It("requires an id", func() {
+			_, err := client.BGPVPN("")
+			Expect(err).To(MatchError("empty 'id' parameter"))
+			_, err = client.BGPVPNPortAssociations("")
+			Expect(err).To(MatchError("empty 'bgpvpnID' parameter"))
+			err = client.DeleteBGPVPNRouterAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", "")
+			Expect(err).To(MatchError("empty 'id' parameter"))
+			Expect(method).To(BeEmpty())
+		})
+	})
+
+	Describe("network associations", func() {
+		It("lists network associations", func() {
+			as, err := client.BGPVPNNetworkAssociations("460ac411-3dfb-45bb-8116-ed1a7233d143")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/network_associations"))
+			Expect(as).To(HaveLen(1))
+			Expect(as[0].NetworkID).To(Equal("a4f2b8df-cb42-4893-a333-d0b5c36ade17"))
+		})
+
+		It("gets a network association", func() {
+			a, err := client.BGPVPNNetworkAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", "73238ca1-e05d-4c7a-b4d4-70407b4b8730")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/network_associations/73238ca1-e05d-4c7a-b4d4-70407b4b8730"))
+			Expect(a.ID).To(Equal("73238ca1-e05d-4c7a-b4d4-70407b4b8730"))
+		})
+
+		It("associates a network", func() {
+			a, err := client.CreateBGPVPNNetworkAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", neutron.BGPVPNNetworkAssociation{
+				NetworkID: "a4f2b8df-cb42-4893-a333-d0b5c36ade17",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/network_associations"))
+			Expect(body).To(MatchJSON(`{"network_association": {"network_id": "a4f2b8df-cb42-4893-a333-d0b5c36ade17"}}`))
+			Expect(a.NetworkID).To(Equal("a4f2b8df-cb42-4893-a333-d0b5c36ade17"))
+		})
+	})
+
+	Describe("router associations", func() {
+		It("lists router associations", func() {
+			as, err := client.BGPVPNRouterAssociations("460ac411-3dfb-45bb-8116-ed1a7233d143")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/router_associations"))
+			Expect(as).To(HaveLen(1))
+			Expect(*as[0].AdvertiseExtraRoutes).To(BeTrue())
+		})
+
+		It("gets a router association", func() {
+			a, err := client.BGPVPNRouterAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", "95cbd6c1-7b8e-4f0f-9d5c-3f6c1b8f3c1d")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/router_associations/95cbd6c1-7b8e-4f0f-9d5c-3f6c1b8f3c1d"))
+			Expect(a.RouterID).To(Equal("b1b3b0b5-5b8a-4c7c-8a86-3b6bd7c7f1f0"))
+			Expect(*a.AdvertiseExtraRoutes).To(BeFalse())
+		})
+
+		It("associates a router", func() {
+			_, err := client.CreateBGPVPNRouterAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", neutron.BGPVPNRouterAssociation{
+				RouterID: "b1b3b0b5-5b8a-4c7c-8a86-3b6bd7c7f1f0",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/router_associations"))
+			Expect(body).To(MatchJSON(`{"router_association": {"router_id": "b1b3b0b5-5b8a-4c7c-8a86-3b6bd7c7f1f0"}}`))
+		})
+
+		It("updates a router association", func() {
+			advertise := false
+			a, err := client.UpdateBGPVPNRouterAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", "95cbd6c1-7b8e-4f0f-9d5c-3f6c1b8f3c1d", neutron.BGPVPNRouterAssociationUpdate{
+				AdvertiseExtraRoutes: &advertise,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/router_associations/95cbd6c1-7b8e-4f0f-9d5c-3f6c1b8f3c1d"))
+			Expect(body).To(MatchJSON(`{"router_association": {"advertise_extra_routes": false}}`))
+			Expect(*a.AdvertiseExtraRoutes).To(BeFalse())
+		})
+
+		It("removes a router association", func() {
+			err := client.DeleteBGPVPNRouterAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", "95cbd6c1-7b8e-4f0f-9d5c-3f6c1b8f3c1d")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/router_associations/95cbd6c1-7b8e-4f0f-9d5c-3f6c1b8f3c1d"))
+		})
+	})
+
+	Describe("port associations", func() {
+		It("lists port associations", func() {
+			as, err := client.BGPVPNPortAssociations("460ac411-3dfb-45bb-8116-ed1a7233d143")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/port_associations"))
+			Expect(as).To(HaveLen(1))
+			Expect(as[0].Routes).To(Equal([]neutron.BGPVPNPortRoute{
+				{Type: "bgpvpn", BGPVPNID: "2dd0d5e6-3c2b-4a8e-b8f7-51d2c2a7a0e2"},
+			}))
+		})
+
+		It("gets a port association", func() {
+			a, err := client.BGPVPNPortAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", "8e4a5f9c-35d5-4fb8-a5fa-3b82e4c76dd8")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/port_associations/8e4a5f9c-35d5-4fb8-a5fa-3b82e4c76dd8"))
+			Expect(*a.AdvertiseFixedIPs).To(BeFalse())
+			Expect(a.Routes[0].LocalPref).To(Equal(100))
+		})
+
+		It("updates the routes of a port association", func() {
+			routes := []neutron.BGPVPNPortRoute{{Type: "bgpvpn", BGPVPNID: "2dd0d5e6-3c2b-4a8e-b8f7-51d2c2a7a0e2", LocalPref: 50}}
+			_, err := client.UpdateBGPVPNPortAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", "8e4a5f9c-35d5-4fb8-a5fa-3b82e4c76dd8", neutron.BGPVPNPortAssociationUpdate{
+				Routes: &routes,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/port_associations/8e4a5f9c-35d5-4fb8-a5fa-3b82e4c76dd8"))
+			Expect(body).To(MatchJSON(`{
+				"port_association": {"routes": [{"type": "bgpvpn", "bgpvpn_id": "2dd0d5e6-3c2b-4a8e-b8f7-51d2c2a7a0e2", "local_pref": 50}]}
+			}`))
+		})
+
+		It("removes a port association", func() {
+			err := client.DeleteBGPVPNPortAssociation("460ac411-3dfb-45bb-8116-ed1a7233d143", "8e4a5f9c-35d5-4fb8-a5fa-3b82e4c76dd8")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/bgpvpn/bgpvpns/460ac411-3dfb-45bb-8116-ed1a7233d143/port_associations/8e4a5f9c-35d5-4fb8-a5fa-3b82e4c76dd8"))
+		})
+	})
+})
@@ -0,0 +1,179 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const DefaultBulkChunkSize = 100
+
+// BulkFailure is an item of a bulk create that was not created.
+type BulkFailure struct {
+	Index int
+	Err   error
+}
+
+// BulkError is returned by bulk creates when some items were not created.
+// Neutron creates the items of a request atomically, so every item of a
+// rejected chunk is reported, with the error of the chunk.
+type BulkError struct {
+	Failures []BulkFailure
+}
+
+func (e *BulkError) Error() string {
+	var msgs []string
+	var last error
+	for _, f := range e.Failures {
+		if f.Err != last {
+			msgs = append(msgs, fmt.Sprintf("item %d: %v", f.Index, f.Err))
+			last = f.Err
+		}
+	}
+	return fmt.Sprintf("%d bulk items failed: %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+// FailedIndexes returns the input indexes of the items not created.
+func (e *BulkError) FailedIndexes() []int {
+	indexes := make([]int, len(e.Failures))
+	for i, f := range e.Failures {
+		indexes[i] = f.Index
+	}
+	return indexes
+}
+
+// bulkCreate calls create for consecutive chunks of n items, carrying on
+// after rejected chunks, and returns a *BulkError listing the items of the
+// rejected chunks.
+func (c *Client) bulkCreate(n int, create func(start, end int) error) error {
+	size := c.BulkChunkSize
+	if size <= 0 {
+		size = DefaultBulkChunkSize
+	}
+
+	var bulkErr BulkError
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		if err := create(start, end); err != nil {
+			for i := start; i < end; i++ {
+				bulkErr.Failures = append(bulkErr.Failures, BulkFailure{Index: i, Err: err})
+			}
+		}
+	}
+	if len(bulkErr.Failures) > 0 {
+		return &bulkErr
+	}
+	return nil
+}
+
+// validateBulk checks every item with validate before anything is sent.
+func validateBulk(n int, validate func(i int) error) error {
+	var bulkErr BulkError
+	for i := 0; i < n; i++ {
+		if err := validate(i); err != nil {
+			bulkErr.Failures = append(bulkErr.Failures, BulkFailure{Index: i, Err: err})
+		}
+	}
+	if len(bulkErr.Failures) > 0 {
+		return &bulkErr
+	}
+	return nil
+}
+
+// BulkCountError is the error of a chunk for which Neutron returned a
+// different number of items than were sent. Items holds the items returned,
+// e.g. a []Network, which Neutron did create.
+type BulkCountError struct {
+	Resource string
+	Expected int
+	Count    int
+	Items    interface{}
+}
+
+func (e *BulkCountError) Error() string {
+	return fmt.Sprintf("bulk create returned %d %s, expected %d", e.Count, e.Resource, e.Expected)
+}
+
+func bulkCountError(resource string, expected, count int, items interface{}) error {
+	return &BulkCountError{Resource: resource, Expected: expected, Count: count, Items: items}
+}
+
+// CreateNetworks creates the networks in bulk requests. The result has the
+// order of the input; networks that failed are left zero valued and listed
+// in the returned *BulkError.
+func (c *Client) CreateNetworks(nets []Network) ([]Network, error) {
+	err := validateBulk(len(nets), func(i int) error {
+		return nets[i].validateDNS()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.requireNetworkAvailabilityZones(nets...); err != nil {
+		return nil, err
+	}
+
+	created := make([]Network, len(nets))
+	err = c.bulkCreate(len(nets), func(start, end int) error {
+		var r GetNetworks
+		err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/networks", c.URL), GetNetworks{Networks: nets[start:end]}, http.StatusCreated, &r)
+		if err != nil {
+			return err
+		}
+		if len(r.Networks) != end-start {
+			return bulkCountError("networks", end-start, len(r.Networks), r.Networks)
+		}
+		copy(created[start:end], r.Networks)
+		return nil
+	})
+	return created, err
+}
+
+// CreateSubnets creates the subnets in bulk requests. The result has the
+// order of the input; subnets that failed are left zero valued and listed in
+// the returned *BulkError.
+func (c *Client) CreateSubnets(subnets []Subnet) ([]Subnet, error) {
+	created := make([]Subnet, len(subnets))
+	err := c.bulkCreate(len(subnets), func(start, end int) error {
+		var r GetSubnets
+		err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/subnets", c.URL), GetSubnets{Subnets: subnets[start:end]}, http.StatusCreated, &r)
+		if err != nil {
+			return err
+		}
+		if len(r.Subnets) != end-start {
+			return bulkCountError("subnets", end-start, len(r.Subnets), r.Subnets)
+		}
+		copy(created[start:end], r.Subnets)
+		return nil
+	})
+	return created, err
+}
+
+// CreatePorts creates the ports in bulk requests. The result has the order
+// of the input; ports that failed are left zero valued and listed in the
+// returned *BulkError.
+func (c *Client) CreatePorts(ports []Port) ([]Port, error) {
+	err := validateBulk(len(ports), func(i int) error {
+		return ports[i].validateDNS()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]Port, len(ports))
+	err = c.bulkCreate(len(ports), func(start, end int) error {
+		var r GetPorts
+		err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/ports", c.URL), GetPorts{Ports: ports[start:end]}, http.StatusCreated, &r)
+		if err != nil {
+			return err
+		}
+		if len(r.Ports) != end-start {
+			return bulkCountError("ports", end-start, len(r.Ports), r.Ports)
+		}
+		copy(created[start:end], r.Ports)
+		return nil
+	})
+	return created, err
+}
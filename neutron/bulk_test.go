@@ -0,0 +1,178 @@
+package neutron_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bulk create", func() {
+	var (
+		client     *neutron.Client
+		server     *httptest.Server
+		chunks     [][]string
+		bodies     [][]byte
+		reject     string
+		drop       string
+		extensions string
+	)
+
+	BeforeEach(func() {
+		chunks = nil
+		bodies = nil
+		reject = ""
+		drop = ""
+		extensions = extensionsResp
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensions)
+				return
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, body)
+			var in map[string][]map[string]interface{}
+			json.Unmarshal(body, &in)
+
+			var names []string
+			for key, items := range in {
+				var kept []map[string]interface{}
+				for _, item := range items {
+					name := item["name"].(string)
+					names = append(names, name)
+					if name == reject {
+						w.WriteHeader(http.StatusConflict)
+						fmt.Fprintln(w, `{"NeutronError": {"message": "IP address already allocated"}}`)
+						chunks = append(chunks, names)
+						return
+					}
+					item["id"] = name + "-id"
+					if name != drop {
+						kept = append(kept, item)
+					}
+				}
+				in[key] = kept
+			}
+			chunks = append(chunks, names)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(in)
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+		client.BulkChunkSize = 2
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	ports := func(names ...string) []neutron.Port {
+		var ps []neutron.Port
+		for _, n := range names {
+			ps = append(ps, neutron.Port{Name: n, NetworkID: "net1"})
+		}
+		return ps
+	}
+
+	It("creates ports in chunks, preserving order", func() {
+		created, err := client.CreatePorts(ports("a", "b", "c", "d", "e"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(chunks).To(Equal([][]string{{"a", "b"}, {"c", "d"}, {"e"}}))
+		Expect(created).To(HaveLen(5))
+		for i, name := range []string{"a", "b", "c", "d", "e"} {
+			Expect(created[i].ID).To(Equal(name + "-id"))
+		}
+	})
+
+	It("reports the items of rejected chunks", func() {
+		reject = "c"
+		created, err := client.CreatePorts(ports("a", "b", "c", "d", "e"))
+		Expect(err).To(HaveOccurred())
+
+		bulkErr, ok := err.(*neutron.BulkError)
+		Expect(ok).To(BeTrue())
+		Expect(bulkErr.FailedIndexes()).To(Equal([]int{2, 3}))
+		Expect(bulkErr.Failures[0].Err.(*neutron.Error).StatusCode).To(Equal(http.StatusConflict))
+		Expect(err.Error()).To(HavePrefix("2 bulk items failed: item 2: "))
+
+		Expect(chunks).To(HaveLen(3))
+		Expect(created[1].ID).To(Equal("b-id"))
+		Expect(created[2].ID).To(BeEmpty())
+		Expect(created[4].ID).To(Equal("e-id"))
+	})
+
+	It("validates every port before sending", func() {
+		ps := ports("a", "b")
+		ps[1].DNSName = "not.a.label"
+		_, err := client.CreatePorts(ps)
+		Expect(err.(*neutron.BulkError).FailedIndexes()).To(Equal([]int{1}))
+		Expect(chunks).To(BeEmpty())
+	})
+
+	It("creates networks in one request when under the chunk size", func() {
+		client.BulkChunkSize = 0
+		var nets []neutron.Network
+		for i := 0; i < 3; i++ {
+			nets = append(nets, neutron.Network{Name: fmt.Sprintf("net%d", i)})
+		}
+		created, err := client.CreateNetworks(nets)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(chunks).To(Equal([][]string{{"net0", "net1", "net2"}}))
+		Expect(created[2].ID).To(Equal("net2-id"))
+	})
+
+	It("creates subnets in chunks", func() {
+		subnets := []neutron.Subnet{
+			{Name: "s1", NetworkID: "net1", CIDR: "10.0.1.0/24", IPVersion: 4},
+			{Name: "s2", NetworkID: "net1", CIDR: "10.0.2.0/24", IPVersion: 4},
+			{Name: "s3", NetworkID: "net1", CIDR: "fd00::/64", IPVersion: 6},
+		}
+		created, err := client.CreateSubnets(subnets)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(chunks).To(Equal([][]string{{"s1", "s2"}, {"s3"}}))
+		Expect(bodies[1]).To(MatchJSON(`{"subnets": [{"name": "s3", "network_id": "net1", "cidr": "fd00::/64", "ip_version": 6}]}`))
+		Expect(created[2].ID).To(Equal("s3-id"))
+		Expect(created[2].IPVersion).To(Equal(6))
+	})
+
+	It("fails the chunk when the response has a different number of items", func() {
+		drop = "b"
+		created, err := client.CreatePorts(ports("a", "b", "c"))
+		bulkErr, ok := err.(*neutron.BulkError)
+		Expect(ok).To(BeTrue())
+		Expect(bulkErr.FailedIndexes()).To(Equal([]int{0, 1}))
+		Expect(bulkErr.Failures[0].Err).To(MatchError("bulk create returned 1 ports, expected 2"))
+		countErr, ok := bulkErr.Failures[0].Err.(*neutron.BulkCountError)
+		Expect(ok).To(BeTrue())
+		Expect(countErr.Items).To(HaveLen(1))
+		Expect(countErr.Items.([]neutron.Port)[0].ID).To(Equal("a-id"))
+		Expect(created[0].ID).To(BeEmpty())
+		Expect(created[2].ID).To(Equal("c-id"))
+	})
+
+	It("sends availability zone hints of networks", func() {
+		_, err := client.CreateNetworks([]neutron.Network{
+			{Name: "net0"},
+			{Name: "net1", AvailabilityZoneHints: []string{"az1"}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bodies[0]).To(MatchJSON(`{"networks": [
+			{"name": "net0", "admin_state_up": false},
+			{"name": "net1", "admin_state_up": false, "availability_zone_hints": ["az1"]}
+		]}`))
+	})
+
+	It("requires the network availability zone extension for zone hints", func() {
+		extensions = `{"extensions": []}`
+		_, err := client.CreateNetworks([]neutron.Network{{Name: "net0", AvailabilityZoneHints: []string{"az1"}}})
+		Expect(neutron.IsExtensionUnsupported(err)).To(BeTrue())
+		Expect(chunks).To(BeEmpty())
+	})
+})
@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 const X_AUTH_TOKEN_HEADER = "X-Auth-Token"
@@ -14,6 +18,7 @@ type request struct {
 	URL          string
 	Method       string
 	Body         []byte
+	Header       http.Header
 	OkStatusCode int
 }
 
@@ -22,9 +27,70 @@ type response struct {
 	StatusCode int
 }
 
+// Error is returned when Neutron responds with an unexpected status code.
+type Error struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("Error: %s details: %s\n", e.Status, e.Body)
+}
+
+// IsNotFound reports whether err is a Neutron 404 response.
+func IsNotFound(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+// ListOpts filters the results of list calls. Filters holds any other
+// attribute filters, e.g. "agent_type" or "status". ChangedSince, when set,
+// only returns resources updated at or after it.
+type ListOpts struct {
+	ProjectID    string
+	Tags         []string
+	TagsAny      []string
+	NotTags      []string
+	NotTagsAny   []string
+	ChangedSince time.Time
+	Filters      map[string]string
+}
+
+func (o ListOpts) values(q url.Values) {
+	for k, v := range o.Filters {
+		q.Set(k, v)
+	}
+	if o.ProjectID != "" {
+		q.Set("project_id", o.ProjectID)
+	}
+	if len(o.Tags) > 0 {
+		q.Set("tags", strings.Join(o.Tags, ","))
+	}
+	if len(o.TagsAny) > 0 {
+		q.Set("tags-any", strings.Join(o.TagsAny, ","))
+	}
+	if len(o.NotTags) > 0 {
+		q.Set("not-tags", strings.Join(o.NotTags, ","))
+	}
+	if len(o.NotTagsAny) > 0 {
+		q.Set("not-tags-any", strings.Join(o.NotTagsAny, ","))
+	}
+	if !o.ChangedSince.IsZero() {
+		q.Set("changed_since", o.ChangedSince.UTC().Format(time.RFC3339))
+	}
+}
+
 type Client struct {
 	URL   string
 	token string
+
+	// BulkChunkSize is the maximum number of resources sent in one bulk
+	// create request; DefaultBulkChunkSize is used when it is zero.
+	BulkChunkSize int
+
+	mu         sync.Mutex
+	extensions []Extension
 }
 
 func NewClient(url, token string) (*Client, error) {
@@ -47,6 +113,9 @@ func (c *Client) doRequest(r request) (response, error) {
 
 	req.Header.Add(X_AUTH_TOKEN_HEADER, c.token)
 	req.Header.Set("Content-Type", "application/json")
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -60,15 +129,88 @@ func (c *Client) doRequest(r request) (response, error) {
 	}
 
 	if resp.StatusCode != r.OkStatusCode {
-		return response{}, fmt.Errorf("Error: %s details: %s\n", resp.Status, body)
+		return response{}, &Error{StatusCode: resp.StatusCode, Status: resp.Status, Body: body}
 	}
 	return response{Body: body, StatusCode: resp.StatusCode}, nil
 }
 
+// send marshals in, if any, as the request body and unmarshals the response
+// body into out, if any.
+func (c *Client) send(method, url string, in interface{}, okStatusCode int, out interface{}) error {
+	return c.sendHeader(method, url, nil, in, okStatusCode, out)
+}
+
+// sendHeader is send with additional request headers.
+func (c *Client) sendHeader(method, url string, header http.Header, in interface{}, okStatusCode int, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("invalid request: %v", err)
+		}
+	}
+
+	resp, err := c.doRequest(request{
+		URL:          url,
+		Method:       method,
+		Body:         body,
+		Header:       header,
+		OkStatusCode: okStatusCode,
+	})
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Body, out)
+}
+
+func (c *Client) listURL(path string, q url.Values, opts []ListOpts) string {
+	if q == nil {
+		q = url.Values{}
+	}
+	for _, o := range opts {
+		o.values(q)
+	}
+	u := fmt.Sprintf("%s/v2.0/%s", c.URL, path)
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	return u
+}
+
+// rollback holds the deletions undoing the steps of a multi-resource
+// operation, in the order the resources were created.
+type rollback []func() error
+
+// run undoes the steps in reverse order and returns err, annotated with the
+// first rollback failure if any.
+func (r rollback) run(err error) error {
+	var rbErr error
+	for i := len(r) - 1; i >= 0; i-- {
+		if e := r[i](); e != nil && rbErr == nil {
+			rbErr = e
+		}
+	}
+	if rbErr != nil {
+		return fmt.Errorf("%v (rollback failed: %v)", err, rbErr)
+	}
+	return err
+}
+
 func (c *Client) CreateNetwork(net Network) (Network, error) {
+	if err := net.validateDNS(); err != nil {
+		return Network{}, err
+	}
+	if err := c.requireNetworkAvailabilityZones(net); err != nil {
+		return Network{}, err
+	}
+
 	jsonStr, err := json.Marshal(SingleNetwork{Network: net})
 	if err != nil {
-		return Network{}, fmt.Errorf("invalid network: ", err)
+		return Network{}, fmt.Errorf("invalid network: %v", err)
 	}
 
 	resp, err := c.doRequest(request{
@@ -90,6 +232,28 @@ func (c *Client) CreateNetwork(net Network) (Network, error) {
 	return r.Network, nil
 }
 
+func (c *Client) UpdateNetwork(id string, u NetworkUpdate) (Network, error) {
+	if id == "" {
+		return Network{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if u.DNSDomain != nil && *u.DNSDomain != "" {
+		if err := ValidateDNSDomain(*u.DNSDomain); err != nil {
+			return Network{}, err
+		}
+	}
+
+	return c.updateNetwork(id, nil, u)
+}
+
+func (c *Client) updateNetwork(id string, header http.Header, u NetworkUpdate) (Network, error) {
+	var r SingleNetwork
+	err := c.sendHeader(http.MethodPut, fmt.Sprintf("%s/v2.0/networks/%s", c.URL, id), header, map[string]NetworkUpdate{"network": u}, http.StatusOK, &r)
+	if err != nil {
+		return Network{}, err
+	}
+	return r.Network, nil
+}
+
 func (c *Client) DeleteNetwork(id string) error {
 	if id == "" {
 		return fmt.Errorf("empty 'id' parameter")
@@ -105,9 +269,9 @@ func (c *Client) DeleteNetwork(id string) error {
 	return nil
 }
 
-func (c *Client) Networks() ([]Network, error) {
+func (c *Client) Networks(opts ...ListOpts) ([]Network, error) {
 	resp, err := c.doRequest(request{
-		URL:          fmt.Sprintf("%s/v2.0/networks", c.URL),
+		URL:          c.listURL("networks", nil, opts),
 		Method:       http.MethodGet,
 		OkStatusCode: http.StatusOK,
 	})
@@ -123,13 +287,35 @@ func (c *Client) Networks() ([]Network, error) {
 	return r.Networks, nil
 }
 
-func (c *Client) NetworksByName(name string) ([]Network, error) {
+func (c *Client) Network(id string) (Network, error) {
+	if id == "" {
+		return Network{}, fmt.Errorf("empty 'id' parameter")
+	}
+
+	resp, err := c.doRequest(request{
+		URL:          fmt.Sprintf("%s/v2.0/networks/%s", c.URL, id),
+		Method:       http.MethodGet,
+		OkStatusCode: http.StatusOK,
+	})
+	if err != nil {
+		return Network{}, err
+	}
+
+	var r SingleNetwork
+	err = json.Unmarshal(resp.Body, &r)
+	if err != nil {
+		return Network{}, err
+	}
+	return r.Network, nil
+}
+
+func (c *Client) NetworksByName(name string, opts ...ListOpts) ([]Network, error) {
 	if name == "" {
 		return nil, fmt.Errorf("empty 'name' parameter")
 	}
 
 	resp, err := c.doRequest(request{
-		URL:          fmt.Sprintf("%s/v2.0/networks?name=%s", c.URL, name),
+		URL:          c.listURL("networks", url.Values{"name": {name}}, opts),
 		Method:       http.MethodGet,
 		OkStatusCode: http.StatusOK,
 	})
@@ -145,9 +331,9 @@ func (c *Client) NetworksByName(name string) ([]Network, error) {
 	return r.Networks, nil
 }
 
-func (c *Client) Subnets() ([]Subnet, error) {
+func (c *Client) Subnets(opts ...ListOpts) ([]Subnet, error) {
 	resp, err := c.doRequest(request{
-		URL:          fmt.Sprintf("%s/v2.0/subnets", c.URL),
+		URL:          c.listURL("subnets", nil, opts),
 		Method:       http.MethodGet,
 		OkStatusCode: http.StatusOK,
 	})
@@ -163,13 +349,13 @@ func (c *Client) Subnets() ([]Subnet, error) {
 	return r.Subnets, nil
 }
 
-func (c *Client) SubnetsByName(name string) ([]Subnet, error) {
+func (c *Client) SubnetsByName(name string, opts ...ListOpts) ([]Subnet, error) {
 	if name == "" {
 		return nil, fmt.Errorf("empty 'name' parameter")
 	}
 
 	resp, err := c.doRequest(request{
-		URL:          fmt.Sprintf("%s/v2.0/subnets?name=%s", c.URL, name),
+		URL:          c.listURL("subnets", url.Values{"name": {name}}, opts),
 		Method:       http.MethodGet,
 		OkStatusCode: http.StatusOK,
 	})
@@ -188,7 +374,7 @@ func (c *Client) SubnetsByName(name string) ([]Subnet, error) {
 func (c *Client) CreateSubnet(s Subnet) (Subnet, error) {
 	jsonStr, err := json.Marshal(SingleSubnet{Subnet: s})
 	if err != nil {
-		return Subnet{}, fmt.Errorf("invalid subnet: ", err)
+		return Subnet{}, fmt.Errorf("invalid subnet: %v", err)
 	}
 
 	resp, err := c.doRequest(request{
@@ -211,10 +397,31 @@ func (c *Client) CreateSubnet(s Subnet) (Subnet, error) {
 	return r.Subnet, nil
 }
 
+func (c *Client) UpdateSubnet(id string, u SubnetUpdate) (Subnet, error) {
+	if id == "" {
+		return Subnet{}, fmt.Errorf("empty 'id' parameter")
+	}
+
+	return c.updateSubnet(id, nil, u)
+}
+
+func (c *Client) updateSubnet(id string, header http.Header, u SubnetUpdate) (Subnet, error) {
+	var r SingleSubnet
+	err := c.sendHeader(http.MethodPut, fmt.Sprintf("%s/v2.0/subnets/%s", c.URL, id), header, map[string]SubnetUpdate{"subnet": u}, http.StatusOK, &r)
+	if err != nil {
+		return Subnet{}, err
+	}
+	return r.Subnet, nil
+}
+
 func (c *Client) CreatePort(p Port) (Port, error) {
+	if err := p.validateDNS(); err != nil {
+		return Port{}, err
+	}
+
 	jsonStr, err := json.Marshal(SinglePort{Port: p})
 	if err != nil {
-		return Port{}, fmt.Errorf("invalid port: ", err)
+		return Port{}, fmt.Errorf("invalid port: %v", err)
 	}
 
 	resp, err := c.doRequest(request{
@@ -237,6 +444,82 @@ func (c *Client) CreatePort(p Port) (Port, error) {
 	return r.Port, nil
 }
 
+func (c *Client) Ports(opts ...ListOpts) ([]Port, error) {
+	resp, err := c.doRequest(request{
+		URL:          c.listURL("ports", nil, opts),
+		Method:       http.MethodGet,
+		OkStatusCode: http.StatusOK,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var r GetPorts
+	err = json.Unmarshal(resp.Body, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Ports, nil
+}
+
+func (c *Client) Port(id string) (Port, error) {
+	if id == "" {
+		return Port{}, fmt.Errorf("empty 'id' parameter")
+	}
+
+	resp, err := c.doRequest(request{
+		URL:          fmt.Sprintf("%s/v2.0/ports/%s", c.URL, id),
+		Method:       http.MethodGet,
+		OkStatusCode: http.StatusOK,
+	})
+	if err != nil {
+		return Port{}, err
+	}
+
+	var r SinglePort
+	err = json.Unmarshal(resp.Body, &r)
+	if err != nil {
+		return Port{}, err
+	}
+	return r.Port, nil
+}
+
+func (c *Client) UpdatePort(id string, u PortUpdate) (Port, error) {
+	if id == "" {
+		return Port{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := u.validateDNS(); err != nil {
+		return Port{}, err
+	}
+
+	return c.updatePort(id, nil, map[string]PortUpdate{"port": u})
+}
+
+func (c *Client) updatePort(id string, header http.Header, body interface{}) (Port, error) {
+	jsonStr, err := json.Marshal(body)
+	if err != nil {
+		return Port{}, fmt.Errorf("invalid port: %v", err)
+	}
+
+	resp, err := c.doRequest(request{
+		URL:          fmt.Sprintf("%s/v2.0/ports/%s", c.URL, id),
+		Method:       http.MethodPut,
+		Body:         jsonStr,
+		Header:       header,
+		OkStatusCode: http.StatusOK,
+	})
+	if err != nil {
+		return Port{}, err
+	}
+
+	var r SinglePort
+	err = json.Unmarshal(resp.Body, &r)
+	if err != nil {
+		return Port{}, err
+	}
+	return r.Port, nil
+}
+
 func (c *Client) DeletePort(id string) error {
 	if id == "" {
 		return fmt.Errorf("empty 'id' parameter")
@@ -0,0 +1,132 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ConntrackHelper enables a netfilter connection tracking helper, such as
+// "ftp" or "tftp", for traffic to Port through the router.
+type ConntrackHelper struct {
+	ID        string `json:"id,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	Port      int    `json:"port,omitempty"`
+	Helper    string `json:"helper,omitempty"`
+	TenantID  string `json:"tenant_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetConntrackHelpers struct {
+	ConntrackHelpers []ConntrackHelper `json:"conntrack_helpers"`
+}
+
+type SingleConntrackHelper struct {
+	ConntrackHelper ConntrackHelper `json:"conntrack_helper"`
+}
+
+// ConntrackHelperUpdate holds the conntrack helper attributes to change; nil
+// fields are left as is.
+type ConntrackHelperUpdate struct {
+	Protocol *string `json:"protocol,omitempty"`
+	Port     *int    `json:"port,omitempty"`
+	Helper   *string `json:"helper,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+const conntrackHelperExtension = "l3-conntrack-helper"
+
+func (c *Client) ConntrackHelpers(routerID string) ([]ConntrackHelper, error) {
+	if routerID == "" {
+		return nil, fmt.Errorf("empty 'routerID' parameter")
+	}
+	if err := c.requireExtension(conntrackHelperExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetConntrackHelpers
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/routers/%s/conntrack_helpers", c.URL, routerID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.ConntrackHelpers, nil
+}
+
+func (c *Client) ConntrackHelper(routerID, id string) (ConntrackHelper, error) {
+	if routerID == "" {
+		return ConntrackHelper{}, fmt.Errorf("empty 'routerID' parameter")
+	}
+	if id == "" {
+		return ConntrackHelper{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(conntrackHelperExtension); err != nil {
+		return ConntrackHelper{}, err
+	}
+
+	var r SingleConntrackHelper
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/routers/%s/conntrack_helpers/%s", c.URL, routerID, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return ConntrackHelper{}, err
+	}
+	return r.ConntrackHelper, nil
+}
+
+func (c *Client) CreateConntrackHelper(routerID string, h ConntrackHelper) (ConntrackHelper, error) {
+	switch {
+	case h.Helper == "":
+		return ConntrackHelper{}, fmt.Errorf("missing conntrack helper name")
+	case h.Protocol == "":
+		return ConntrackHelper{}, fmt.Errorf("missing protocol")
+	case h.Port <= 0 || h.Port > 65535:
+		return ConntrackHelper{}, fmt.Errorf("invalid port %d", h.Port)
+	}
+	if routerID == "" {
+		return ConntrackHelper{}, fmt.Errorf("empty 'routerID' parameter")
+	}
+	if err := c.requireExtension(conntrackHelperExtension); err != nil {
+		return ConntrackHelper{}, err
+	}
+
+	var r SingleConntrackHelper
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/routers/%s/conntrack_helpers", c.URL, routerID), SingleConntrackHelper{ConntrackHelper: h}, http.StatusCreated, &r)
+	if err != nil {
+		return ConntrackHelper{}, err
+	}
+	return r.ConntrackHelper, nil
+}
+
+func (c *Client) UpdateConntrackHelper(routerID, id string, u ConntrackHelperUpdate) (ConntrackHelper, error) {
+	if routerID == "" {
+		return ConntrackHelper{}, fmt.Errorf("empty 'routerID' parameter")
+	}
+	if id == "" {
+		return ConntrackHelper{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(conntrackHelperExtension); err != nil {
+		return ConntrackHelper{}, err
+	}
+
+	var r SingleConntrackHelper
+	body := map[string]ConntrackHelperUpdate{"conntrack_helper": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/routers/%s/conntrack_helpers/%s", c.URL, routerID, id), body, http.StatusOK, &r)
+	if err != nil {
+		return ConntrackHelper{}, err
+	}
+	return r.ConntrackHelper, nil
+}
+
+func (c *Client) DeleteConntrackHelper(routerID, id string) error {
+	if routerID == "" {
+		return fmt.Errorf("empty 'routerID' parameter")
+	}
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(conntrackHelperExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/routers/%s/conntrack_helpers/%s", c.URL, routerID, id), nil, http.StatusNoContent, nil)
+}
@@ -0,0 +1,139 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const conntrackHelpersResp = `{
+  "conntrack_helpers": [
+    {
+      "id": "6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f",
+      "protocol": "tcp",
+      "port": 21,
+      "helper": "ftp"
+    }
+  ]
+}`
+
+const conntrackHelperResp = `{
+  "conntrack_helper": {
+    "id": "6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f",
+    "protocol": "tcp",
+    "port": 6667,
+    "helper": "irc"
+  }
+}`
+
+var _ = Describe("Conntrack helpers", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		method   string
+		path     string
+		body     []byte
+		requests int
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		method, path, body = "", "", nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests++
+			method = r.Method
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			switch r.Method {
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body)
+			case http.MethodPut:
+				fmt.Fprintln(w, `{"conntrack_helper": {"id": "6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f", "protocol": "udp", "port": 69, "helper": "tftp"}}`)
+			default:
+				if r.URL.Path == "/v2.0/routers/router1/conntrack_helpers/6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f" {
+					fmt.Fprintln(w, conntrackHelperResp)
+					return
+				}
+				fmt.Fprintln(w, conntrackHelpersResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists the helpers of a router", func() {
+		helpers, err := client.ConntrackHelpers("router1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/routers/router1/conntrack_helpers"))
+		Expect(helpers).To(Equal([]neutron.ConntrackHelper{
+			{ID: "6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f", Protocol: "tcp", Port: 21, Helper: "ftp"},
+		}))
+	})
+
+	It("creates a helper", func() {
+		_, err := client.CreateConntrackHelper("router1", neutron.ConntrackHelper{Protocol: "tcp", Port: 21, Helper: "ftp"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(body).To(MatchJSON(`{"conntrack_helper": {"protocol": "tcp", "port": 21, "helper": "ftp"}}`))
+	})
+
+	It("rejects invalid ports", func() {
+		_, err := client.CreateConntrackHelper("router1", neutron.ConntrackHelper{Protocol: "udp", Port: 70000, Helper: "tftp"})
+		Expect(err).To(MatchError("invalid port 70000"))
+		Expect(requests).To(Equal(0))
+	})
+
+	It("updates a helper", func() {
+		protocol, port, helper := "udp", 69, "tftp"
+		h, err := client.UpdateConntrackHelper("router1", "6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f", neutron.ConntrackHelperUpdate{
+			Protocol: &protocol,
+			Port:     &port,
+			Helper:   &helper,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/routers/router1/conntrack_helpers/6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f"))
+		Expect(body).To(MatchJSON(`{"conntrack_helper": {"protocol": "udp", "port": 69, "helper": "tftp"}}`))
+		Expect(h.Helper).To(Equal("tftp"))
+	})
+
+	It("gets a helper", func() {
+		h, err := client.ConntrackHelper("router1", "6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodGet))
+		Expect(path).To(Equal("/v2.0/routers/router1/conntrack_helpers/6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f"))
+		Expect(h).To(Equal(neutron.ConntrackHelper{ID: "6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f", Protocol: "tcp", Port: 6667, Helper: "irc"}))
+	})
+
+	It("deletes a helper", func() {
+		err := client.DeleteConntrackHelper("router1", "6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/routers/router1/conntrack_helpers/6ae7a5b4-1a2b-4c3d-8e9f-0a1b2c3d4e5f"))
+	})
+
+	It("requires a router and an id", func() {
+		_, err := client.ConntrackHelpers("")
+		Expect(err).To(MatchError("empty 'routerID' parameter"))
+		err = client.DeleteConntrackHelper("router1", "")
+		Expect(err).To(MatchError("empty 'id' parameter"))
+		Expect(requests).To(Equal(0))
+	})
+})
@@ -0,0 +1,132 @@
+package neutron
+
+import (
+	"fmt"
+	"strings"
+)
+
+type DNSAssignment struct {
+	Hostname  string `json:"hostname"`
+	IPAddress string `json:"ip_address"`
+	FQDN      string `json:"fqdn"`
+}
+
+// ValidateDNSName checks that name is a single valid DNS label, as required
+// for the dns_name of ports and floating IPs.
+func ValidateDNSName(name string) error {
+	if strings.Contains(strings.TrimSuffix(name, "."), ".") {
+		return fmt.Errorf("invalid DNS name '%s': must be a single label", name)
+	}
+	return validateDNSLabel(strings.TrimSuffix(name, "."))
+}
+
+// ValidateDNSDomain checks that domain is a valid, possibly dot terminated,
+// domain name.
+func ValidateDNSDomain(domain string) error {
+	trimmed := strings.TrimSuffix(domain, ".")
+	if trimmed == "" {
+		return fmt.Errorf("invalid DNS domain '%s'", domain)
+	}
+	if len(trimmed) > 253 {
+		return fmt.Errorf("invalid DNS domain '%s': longer than 253 characters", domain)
+	}
+	for _, label := range strings.Split(trimmed, ".") {
+		if err := validateDNSLabel(label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDNS checks the DNS domain of the network, if any.
+func (net Network) validateDNS() error {
+	if net.DNSDomain != "" {
+		return ValidateDNSDomain(net.DNSDomain)
+	}
+	return nil
+}
+
+// validateDNS checks the DNS name and domain of the port, if any.
+func (p Port) validateDNS() error {
+	if p.DNSName != "" {
+		if err := ValidateDNSName(p.DNSName); err != nil {
+			return err
+		}
+	}
+	if p.DNSDomain != "" {
+		return ValidateDNSDomain(p.DNSDomain)
+	}
+	return nil
+}
+
+// validateDNS checks the DNS name and domain set by the update, if any. Empty
+// values clear the attributes and are not checked.
+func (u PortUpdate) validateDNS() error {
+	if u.DNSName != nil && *u.DNSName != "" {
+		if err := ValidateDNSName(*u.DNSName); err != nil {
+			return err
+		}
+	}
+	if u.DNSDomain != nil && *u.DNSDomain != "" {
+		if err := ValidateDNSDomain(*u.DNSDomain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateDNSLabel(label string) error {
+	if label == "" {
+		return fmt.Errorf("invalid DNS label: empty")
+	}
+	if len(label) > 63 {
+		return fmt.Errorf("invalid DNS label '%s': longer than 63 characters", label)
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return fmt.Errorf("invalid DNS label '%s': cannot start or end with '-'", label)
+	}
+	for _, r := range label {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return fmt.Errorf("invalid DNS label '%s': invalid character '%c'", label, r)
+		}
+	}
+	return nil
+}
+
+// FQDNs returns the fully qualified domain names of the port. They are taken
+// from dns_assignment when present, otherwise built from dns_name and
+// dns_domain. No names are returned when the port has no DNS name.
+func (p Port) FQDNs() ([]string, error) {
+	if p.DNSName == "" {
+		return nil, nil
+	}
+
+	var fqdns []string
+	seen := map[string]bool{}
+
+	for _, a := range p.DNSAssignment {
+		if a.FQDN == "" || seen[a.FQDN] {
+			continue
+		}
+		if err := ValidateDNSDomain(a.FQDN); err != nil {
+			return nil, err
+		}
+		seen[a.FQDN] = true
+		fqdns = append(fqdns, a.FQDN)
+	}
+	if len(fqdns) > 0 {
+		return fqdns, nil
+	}
+
+	if err := ValidateDNSName(p.DNSName); err != nil {
+		return nil, err
+	}
+	if p.DNSDomain == "" {
+		return nil, fmt.Errorf("port '%s' has no DNS domain", p.ID)
+	}
+	if err := ValidateDNSDomain(p.DNSDomain); err != nil {
+		return nil, err
+	}
+	fqdn := strings.TrimSuffix(p.DNSName, ".") + "." + strings.TrimSuffix(p.DNSDomain, ".") + "."
+	return []string{fqdn}, nil
+}
@@ -0,0 +1,177 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const dnsPortResp = `{
+  "port": {
+    "admin_state_up": true,
+    "dns_assignment": [
+      {
+        "hostname": "myport",
+        "ip_address": "10.0.0.4",
+        "fqdn": "myport.example.org."
+      },
+      {
+        "hostname": "myport",
+        "ip_address": "fd5d:7b07:f2ff::3",
+        "fqdn": "myport.example.org."
+      }
+    ],
+    "dns_domain": "example.org.",
+    "dns_name": "myport",
+    "id": "ebe69f1e-bc26-4db5-bed0-c0afb4afe3db",
+    "network_id": "6aeaf34a-c482-4bd3-9dc3-7faf36412f12"
+  }
+}`
+
+const dnsFloatingIPResp = `{
+  "floatingip": {
+    "id": "2f245a7b-796b-4f26-9cf9-9e82d248fda7",
+    "floating_ip_address": "172.24.4.228",
+    "floating_network_id": "376da547-b977-4cfe-9cba-275c80debf57",
+    "router_id": "d23abc8d-2991-4a55-ba98-2aaea84cc72f",
+    "port_id": "ce705c24-c1ef-408a-bda3-7bbd946164ab",
+    "fixed_ip_address": "10.0.0.3",
+    "status": "ACTIVE",
+    "dns_name": "myfip",
+    "dns_domain": "example.org."
+  }
+}`
+
+var _ = Describe("DNS integration", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		body     []byte
+		requests int
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			body, _ = ioutil.ReadAll(r.Body)
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+			switch {
+			case strings.HasPrefix(r.URL.Path, "/v2.0/floatingips"):
+				fmt.Fprintln(w, dnsFloatingIPResp)
+			case strings.HasPrefix(r.URL.Path, "/v2.0/networks"):
+				w.Write([]byte(createNetworkResp))
+			default:
+				fmt.Fprintln(w, dnsPortResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reads DNS attributes of a port", func() {
+		p, err := client.Port("port1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(p.DNSName).To(Equal("myport"))
+		Expect(p.DNSDomain).To(Equal("example.org."))
+		Expect(p.DNSAssignment).To(HaveLen(2))
+		Expect(p.DNSAssignment[1].IPAddress).To(Equal("fd5d:7b07:f2ff::3"))
+	})
+
+	It("reads DNS attributes of a floating IP", func() {
+		fip, err := client.FloatingIP("2f245a7b-796b-4f26-9cf9-9e82d248fda7")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fip.FloatingIPAddress).To(Equal("172.24.4.228"))
+		Expect(fip.DNSName).To(Equal("myfip"))
+		Expect(fip.DNSDomain).To(Equal("example.org."))
+	})
+
+	It("sends the DNS domain of a network", func() {
+		_, err := client.CreateNetwork(neutron.Network{Name: "net1", DNSDomain: "example.org."})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"network": {"name": "net1", "admin_state_up": false, "dns_domain": "example.org."}}`))
+	})
+
+	It("sends the DNS name of a port", func() {
+		name := "web1"
+		_, err := client.UpdatePort("port1", neutron.PortUpdate{DNSName: &name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"port": {"dns_name": "web1"}}`))
+	})
+
+	It("rejects invalid DNS names before sending", func() {
+		_, err := client.CreatePort(neutron.Port{NetworkID: "network1", DNSName: "web_1"})
+		Expect(err).To(MatchError("invalid DNS label 'web_1': invalid character '_'"))
+		_, err = client.CreateFloatingIP(neutron.FloatingIP{FloatingNetworkID: "ext", DNSName: "a.b"})
+		Expect(err).To(MatchError("invalid DNS name 'a.b': must be a single label"))
+		Expect(requests).To(Equal(0))
+	})
+
+	It("rejects invalid DNS names and domains of port updates before sending", func() {
+		name, domain := "web_1", "example..org."
+		_, err := client.UpdatePort("port1", neutron.PortUpdate{DNSName: &name})
+		Expect(err).To(MatchError("invalid DNS label 'web_1': invalid character '_'"))
+		_, err = client.UpdatePort("port1", neutron.PortUpdate{DNSDomain: &domain})
+		Expect(err).To(MatchError("invalid DNS label: empty"))
+		_, err = client.UpdatePortIfMatch("port1", 3, neutron.PortUpdate{DNSDomain: &domain})
+		Expect(err).To(MatchError("invalid DNS label: empty"))
+		Expect(requests).To(Equal(0))
+	})
+
+	Describe("FQDNs", func() {
+		It("returns the assigned FQDNs", func() {
+			p, err := client.Port("port1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(p.FQDNs()).To(Equal([]string{"myport.example.org."}))
+		})
+
+		It("builds the FQDN from the DNS name and domain", func() {
+			p := neutron.Port{DNSName: "myport", DNSDomain: "example.org"}
+			Expect(p.FQDNs()).To(Equal([]string{"myport.example.org."}))
+		})
+
+		It("returns no FQDNs without a DNS name", func() {
+			Expect(neutron.Port{}.FQDNs()).To(BeEmpty())
+		})
+
+		It("ignores the DNS assignment without a DNS name", func() {
+			p := neutron.Port{DNSAssignment: []neutron.DNSAssignment{{FQDN: "host-10-0-0-5.example.org."}}}
+			Expect(p.FQDNs()).To(BeNil())
+		})
+
+		It("validates labels", func() {
+			p := neutron.Port{DNSName: "-bad", DNSDomain: "example.org"}
+			_, err := p.FQDNs()
+			Expect(err).To(MatchError("invalid DNS label '-bad': cannot start or end with '-'"))
+		})
+	})
+
+	Describe("ValidateDNSDomain", func() {
+		It("accepts dot terminated domains", func() {
+			Expect(neutron.ValidateDNSDomain("example.org.")).To(Succeed())
+		})
+
+		It("rejects long labels", func() {
+			err := neutron.ValidateDNSDomain(strings.Repeat("a", 64) + ".org")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects empty labels", func() {
+			Expect(neutron.ValidateDNSDomain("example..org")).To(MatchError("invalid DNS label: empty"))
+		})
+	})
+})
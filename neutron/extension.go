@@ -0,0 +1,119 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type Version struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Links  []Link `json:"links,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type Link struct {
+	Href string `json:"href"`
+	Rel  string `json:"rel"`
+}
+
+type GetVersions struct {
+	Versions []Version `json:"versions"`
+}
+
+type Extension struct {
+	Alias       string `json:"alias"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Updated     string `json:"updated"`
+	Links       []Link `json:"links,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetExtensions struct {
+	Extensions []Extension `json:"extensions"`
+}
+
+// ExtensionUnsupportedError is returned by calls that depend on a Neutron
+// extension the server does not advertise.
+type ExtensionUnsupportedError struct {
+	Aliases []string
+}
+
+func (e *ExtensionUnsupportedError) Error() string {
+	return fmt.Sprintf("extension '%s' is not supported", strings.Join(e.Aliases, "' or '"))
+}
+
+// IsExtensionUnsupported reports whether err is an ExtensionUnsupportedError.
+func IsExtensionUnsupported(err error) bool {
+	_, ok := err.(*ExtensionUnsupportedError)
+	return ok
+}
+
+func (c *Client) Versions() ([]Version, error) {
+	var r GetVersions
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/", strings.TrimSuffix(c.URL, "/")), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Versions, nil
+}
+
+// Extensions returns the extensions enabled on the server. The result is
+// cached until RefreshExtensions is called.
+func (c *Client) Extensions() ([]Extension, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.extensions == nil {
+		var r GetExtensions
+		err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/extensions", c.URL), nil, http.StatusOK, &r)
+		if err != nil {
+			return nil, err
+		}
+		if r.Extensions == nil {
+			r.Extensions = []Extension{}
+		}
+		c.extensions = r.Extensions
+	}
+	return c.extensions, nil
+}
+
+// RefreshExtensions discards the cached extensions so that the next call
+// queries the server again.
+func (c *Client) RefreshExtensions() {
+	c.mu.Lock()
+	c.extensions = nil
+	c.mu.Unlock()
+}
+
+func (c *Client) HasExtension(alias string) (bool, error) {
+	exts, err := c.Extensions()
+	if err != nil {
+		return false, err
+	}
+	for _, e := range exts {
+		if e.Alias == alias {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// requireExtension returns an ExtensionUnsupportedError unless the server
+// advertises at least one of the given aliases.
+func (c *Client) requireExtension(aliases ...string) error {
+	for _, alias := range aliases {
+		ok, err := c.HasExtension(alias)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return &ExtensionUnsupportedError{Aliases: aliases}
+}
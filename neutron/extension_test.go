@@ -0,0 +1,362 @@
+package neutron_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const versionsResp = `{
+  "versions": [
+    {
+      "status": "CURRENT",
+      "id": "v2.0",
+      "links": [
+        {
+          "href": "http://controller:9696/v2.0/",
+          "rel": "self"
+        }
+      ]
+    }
+  ]
+}`
+
+const extensionsResp = `{
+  "extensions": [
+    {
+      "alias": "standard-attr-tag",
+      "name": "Tag support for resources with standard attribute",
+      "description": "Enables to set tag on resources with standard attribute.",
+      "updated": "2017-01-01T00:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "quota_details",
+      "name": "Quota details management support",
+      "description": "Expose functions for quotas usage statistics per project",
+      "updated": "2017-02-10T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "network-ip-availability",
+      "name": "Network IP Availability",
+      "description": "Provides IP availability data for each network and subnet.",
+      "updated": "2015-09-24T00:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "agent",
+      "name": "agent",
+      "description": "",
+      "updated": "2013-02-03T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "dhcp_agent_scheduler",
+      "name": "DHCP Agent Scheduler",
+      "description": "",
+      "updated": "2013-02-03T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "l3_agent_scheduler",
+      "name": "L3 Agent Scheduler",
+      "description": "",
+      "updated": "2013-02-03T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "floating-ip-port-forwarding",
+      "name": "Floating IP Port Forwarding",
+      "description": "Allow user to forward floating IP to internal ports.",
+      "updated": "2018-05-07T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "binding-extended",
+      "name": "Port Bindings Extended",
+      "description": "Expose port bindings of a virtual port to external application",
+      "updated": "2017-07-17T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "allowed-address-pairs",
+      "name": "Allowed Address Pairs",
+      "description": "",
+      "updated": "2013-07-23T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "extra_dhcp_opt",
+      "name": "Neutron Extra DHCP options",
+      "description": "",
+      "updated": "2013-07-23T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "port-security",
+      "name": "Port Security",
+      "description": "",
+      "updated": "2013-07-23T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "fwaas_v2",
+      "name": "Firewall service v2",
+      "description": "Extension for Firewall service v2",
+      "updated": "2016-08-16T00:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "vpnaas",
+      "name": "VPN service",
+      "description": "Extension for VPN service",
+      "updated": "2013-05-29T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "metering",
+      "name": "Neutron Metering",
+      "description": "Neutron Metering extension.",
+      "updated": "2013-06-12T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "logging",
+      "name": "Logging API Extension",
+      "description": "Provides a logging API.",
+      "updated": "2017-01-01T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "sfc",
+      "name": "Port Chaining",
+      "description": "Service Function Chaining.",
+      "updated": "2015-10-05T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "flow_classifier",
+      "name": "Flow Classifier",
+      "description": "Flow classifier for service function chaining.",
+      "updated": "2015-10-05T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "service_graph",
+      "name": "Service Graph",
+      "description": "Service graph of port chains.",
+      "updated": "2017-09-20T00:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "bgpvpn",
+      "name": "BGPVPN extension",
+      "description": "Extension for BGPVPN service",
+      "updated": "2014-06-10T17:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "bgp",
+      "name": "BGP Dynamic Routing Extension",
+      "description": "Discover and advertise routes with BGP.",
+      "updated": "2016-05-10T15:37:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "bgp_dragent_scheduler",
+      "name": "BGP Dynamic Routing Agent Scheduler",
+      "description": "Schedules BGP speakers onto BGP dynamic routing agents.",
+      "updated": "2015-07-30T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "auto-allocated-topology",
+      "name": "Auto Allocated Topology Services",
+      "description": "Auto Allocated Topology Services.",
+      "updated": "2016-01-01T00:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "availability_zone",
+      "name": "Availability Zone",
+      "description": "The availability zone extension.",
+      "updated": "2015-01-01T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "network_availability_zone",
+      "name": "Network Availability Zone",
+      "description": "Availability zone support for network.",
+      "updated": "2015-01-01T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "router_availability_zone",
+      "name": "Router Availability Zone",
+      "description": "Availability zone support for router.",
+      "updated": "2015-01-01T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "address-group",
+      "name": "Address group",
+      "description": "Support address group",
+      "updated": "2020-02-28T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "local_ip",
+      "name": "Local IP",
+      "description": "Support Local IPs",
+      "updated": "2021-07-19T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "l3-ndp-proxy",
+      "name": "Router NDP proxy",
+      "description": "Router NDP proxy for IPv6 addresses",
+      "updated": "2021-10-20T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "l3-conntrack-helper",
+      "name": "Router conntrack helper",
+      "description": "Router conntrack helpers",
+      "updated": "2019-03-02T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "flavors",
+      "name": "Neutron Service Flavors",
+      "description": "Flavor specification for Neutron advanced services.",
+      "updated": "2015-09-17T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "l3-flavors",
+      "name": "Router Flavor Extension",
+      "description": "Flavor support for routers.",
+      "updated": "2016-05-17T00:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "network-segment-range",
+      "name": "Neutron Network Segment Range",
+      "description": "Provides support for the network segment range management",
+      "updated": "2018-11-29T00:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "taas",
+      "name": "Neutron Tap as a Service",
+      "description": "Neutron Tap as a Service Extension.",
+      "updated": "2015-01-14T10:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "taas-vlan-filter",
+      "name": "VLAN filtering for Neutron Tap as a Service",
+      "description": "VLAN filter support for tap flows.",
+      "updated": "2019-01-01T00:00:00-00:00",
+      "links": []
+    },
+    {
+      "alias": "revision-if-match",
+      "name": "If-Match constraints based on revision_number",
+      "description": "Allow users to specify that an update or delete should only proceed if the revision matches.",
+      "updated": "2016-12-11T00:00:00-00:00",
+      "links": []
+    }
+  ]
+}`
+
+var _ = Describe("Extensions", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		requests int
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/":
+				fmt.Fprintln(w, versionsResp)
+			case "/v2.0/extensions":
+				requests++
+				fmt.Fprintln(w, extensionsResp)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Versions", func() {
+		It("lists API versions", func() {
+			versions, err := client.Versions()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+			Expect(versions[0].ID).To(Equal("v2.0"))
+			Expect(versions[0].Status).To(Equal("CURRENT"))
+			Expect(versions[0].Links[0].Href).To(Equal("http://controller:9696/v2.0/"))
+		})
+	})
+
+	Describe("Extensions", func() {
+		It("lists enabled extensions", func() {
+			exts, err := client.Extensions()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exts).ToNot(BeEmpty())
+			Expect(exts[0].Alias).To(Equal("standard-attr-tag"))
+			Expect(exts[1].Name).To(Equal("Quota details management support"))
+		})
+
+		It("caches extensions until refreshed", func() {
+			_, err := client.Extensions()
+			Expect(err).ToNot(HaveOccurred())
+			_, err = client.HasExtension("trunk")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(1))
+
+			client.RefreshExtensions()
+			_, err = client.Extensions()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(2))
+		})
+	})
+
+	Describe("HasExtension", func() {
+		It("reports whether an extension is enabled", func() {
+			Expect(client.HasExtension("standard-attr-tag")).To(BeTrue())
+			Expect(client.HasExtension("trunk")).To(BeFalse())
+		})
+	})
+
+	Context("when an extension is not supported", func() {
+		BeforeEach(func() {
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintln(w, `{"extensions": []}`)
+			})
+		})
+
+		It("fails fast with a typed error", func() {
+			err := client.AddTag(neutron.NetworksResource, "network1", "red")
+			Expect(neutron.IsExtensionUnsupported(err)).To(BeTrue())
+			Expect(err).To(MatchError("extension 'standard-attr-tag' or 'tag-ext' or 'tag' is not supported"))
+		})
+	})
+})
@@ -0,0 +1,776 @@
+package neutron
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Extras holds the attributes of a resource that have no field of their own,
+// such as "provider:network_type" or plugin specific attributes. They are
+// kept when decoding responses and sent along with the other attributes on
+// create and update, except for the read only attributes Neutron computes
+// itself.
+type Extras map[string]json.RawMessage
+
+// Has reports whether the attribute is set.
+func (e Extras) Has(key string) bool {
+	_, ok := e[key]
+	return ok
+}
+
+// Get decodes the attribute into v.
+func (e Extras) Get(key string, v interface{}) error {
+	raw, ok := e[key]
+	if !ok {
+		return fmt.Errorf("missing attribute '%s'", key)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid attribute '%s': %v", key, err)
+	}
+	return nil
+}
+
+func (e Extras) String(key string) (string, error) {
+	var s string
+	err := e.Get(key, &s)
+	return s, err
+}
+
+func (e Extras) Int(key string) (int, error) {
+	var i int
+	err := e.Get(key, &i)
+	return i, err
+}
+
+func (e Extras) Bool(key string) (bool, error) {
+	var b bool
+	err := e.Get(key, &b)
+	return b, err
+}
+
+// Set encodes v as the attribute.
+func (e *Extras) Set(key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("invalid attribute '%s': %v", key, err)
+	}
+	if *e == nil {
+		*e = Extras{}
+	}
+	(*e)[key] = raw
+	return nil
+}
+
+func (e Extras) Delete(key string) {
+	delete(e, key)
+}
+
+// knownKeys caches the JSON attribute names of the fields of each type.
+var knownKeys sync.Map
+
+func jsonKeys(t reflect.Type) map[string]bool {
+	if keys, ok := knownKeys.Load(t); ok {
+		return keys.(map[string]bool)
+	}
+	keys := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		keys[name] = true
+	}
+	knownKeys.Store(t, keys)
+	return keys
+}
+
+// unmarshalExtras decodes data into v, a pointer to a struct without
+// UnmarshalJSON method, and the attributes that have no field in v, and are
+// not listed in known, into extras.
+func unmarshalExtras(data []byte, v interface{}, extras *Extras, known ...string) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for key := range jsonKeys(reflect.TypeOf(v).Elem()) {
+		delete(all, key)
+	}
+	for _, key := range known {
+		delete(all, key)
+	}
+	*extras = nil
+	if len(all) > 0 {
+		*extras = Extras(all)
+	}
+	return nil
+}
+
+// readOnlyAttributes are computed by Neutron and rejected in requests. They
+// are kept in Extras when decoding but never sent, so that a fetched resource
+// can be passed to a create call.
+var readOnlyAttributes = map[string]bool{
+	"availability_zones": true,
+	"created_at":         true,
+	"updated_at":         true,
+	"revision_number":    true,
+	"status":             true,
+	"ipv4_address_scope": true,
+	"ipv6_address_scope": true,
+	"l2_adjacency":       true,
+	"ip_allocation":      true,
+	"resource_request":   true,
+	"port_details":       true,
+}
+
+// marshalExtras encodes v, a struct without MarshalJSON method, adding the
+// extras but the read only ones. Attributes with a field in v take precedence
+// over extras.
+func marshalExtras(v interface{}, extras Extras) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extras) == 0 {
+		return data, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for key, raw := range extras {
+		if _, ok := all[key]; !ok && !readOnlyAttributes[key] {
+			all[key] = raw
+		}
+	}
+	return json.Marshal(all)
+}
+
+func (v *AddressGroup) UnmarshalJSON(data []byte) error {
+	type plain AddressGroup
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *Agent) UnmarshalJSON(data []byte) error {
+	type plain Agent
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *AutoAllocatedTopology) UnmarshalJSON(data []byte) error {
+	type plain AutoAllocatedTopology
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *AvailabilityZone) UnmarshalJSON(data []byte) error {
+	type plain AvailabilityZone
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *BGPSpeaker) UnmarshalJSON(data []byte) error {
+	type plain BGPSpeaker
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *BGPPeer) UnmarshalJSON(data []byte) error {
+	type plain BGPPeer
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *BGPVPN) UnmarshalJSON(data []byte) error {
+	type plain BGPVPN
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *BGPVPNNetworkAssociation) UnmarshalJSON(data []byte) error {
+	type plain BGPVPNNetworkAssociation
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *BGPVPNRouterAssociation) UnmarshalJSON(data []byte) error {
+	type plain BGPVPNRouterAssociation
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *BGPVPNPortAssociation) UnmarshalJSON(data []byte) error {
+	type plain BGPVPNPortAssociation
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *ConntrackHelper) UnmarshalJSON(data []byte) error {
+	type plain ConntrackHelper
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *Version) UnmarshalJSON(data []byte) error {
+	type plain Version
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *Extension) UnmarshalJSON(data []byte) error {
+	type plain Extension
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *FirewallGroup) UnmarshalJSON(data []byte) error {
+	type plain FirewallGroup
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *FirewallPolicy) UnmarshalJSON(data []byte) error {
+	type plain FirewallPolicy
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *FirewallRule) UnmarshalJSON(data []byte) error {
+	type plain FirewallRule
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *Flavor) UnmarshalJSON(data []byte) error {
+	type plain Flavor
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *ServiceProfile) UnmarshalJSON(data []byte) error {
+	type plain ServiceProfile
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *NetworkIPAvailability) UnmarshalJSON(data []byte) error {
+	type plain NetworkIPAvailability
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *LocalIP) UnmarshalJSON(data []byte) error {
+	type plain LocalIP
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *LocalIPAssociation) UnmarshalJSON(data []byte) error {
+	type plain LocalIPAssociation
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *NetworkLog) UnmarshalJSON(data []byte) error {
+	type plain NetworkLog
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *LoggableResource) UnmarshalJSON(data []byte) error {
+	type plain LoggableResource
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *MeteringLabel) UnmarshalJSON(data []byte) error {
+	type plain MeteringLabel
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *MeteringLabelRule) UnmarshalJSON(data []byte) error {
+	type plain MeteringLabelRule
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *NDPProxy) UnmarshalJSON(data []byte) error {
+	type plain NDPProxy
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *PortBinding) UnmarshalJSON(data []byte) error {
+	type plain PortBinding
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *PortForwarding) UnmarshalJSON(data []byte) error {
+	type plain PortForwarding
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *Quota) UnmarshalJSON(data []byte) error {
+	type plain Quota
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *QuotaDetails) UnmarshalJSON(data []byte) error {
+	type plain QuotaDetails
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *NetworkSegmentRange) UnmarshalJSON(data []byte) error {
+	type plain NetworkSegmentRange
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *PortPair) UnmarshalJSON(data []byte) error {
+	type plain PortPair
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *PortPairGroup) UnmarshalJSON(data []byte) error {
+	type plain PortPairGroup
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *FlowClassifier) UnmarshalJSON(data []byte) error {
+	type plain FlowClassifier
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *PortChain) UnmarshalJSON(data []byte) error {
+	type plain PortChain
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *ServiceGraph) UnmarshalJSON(data []byte) error {
+	type plain ServiceGraph
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *TapService) UnmarshalJSON(data []byte) error {
+	type plain TapService
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *TapFlow) UnmarshalJSON(data []byte) error {
+	type plain TapFlow
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *IKEPolicy) UnmarshalJSON(data []byte) error {
+	type plain IKEPolicy
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *IPsecPolicy) UnmarshalJSON(data []byte) error {
+	type plain IPsecPolicy
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *VPNService) UnmarshalJSON(data []byte) error {
+	type plain VPNService
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *EndpointGroup) UnmarshalJSON(data []byte) error {
+	type plain EndpointGroup
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v *IPsecSiteConnection) UnmarshalJSON(data []byte) error {
+	type plain IPsecSiteConnection
+	return unmarshalExtras(data, (*plain)(v), &v.Extras)
+}
+
+func (v AddressGroup) MarshalJSON() ([]byte, error) {
+	type plain AddressGroup
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v Agent) MarshalJSON() ([]byte, error) {
+	type plain Agent
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v AutoAllocatedTopology) MarshalJSON() ([]byte, error) {
+	type plain AutoAllocatedTopology
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v AvailabilityZone) MarshalJSON() ([]byte, error) {
+	type plain AvailabilityZone
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v BGPSpeaker) MarshalJSON() ([]byte, error) {
+	type plain BGPSpeaker
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v BGPPeer) MarshalJSON() ([]byte, error) {
+	type plain BGPPeer
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v BGPVPN) MarshalJSON() ([]byte, error) {
+	type plain BGPVPN
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v BGPVPNNetworkAssociation) MarshalJSON() ([]byte, error) {
+	type plain BGPVPNNetworkAssociation
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v BGPVPNRouterAssociation) MarshalJSON() ([]byte, error) {
+	type plain BGPVPNRouterAssociation
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v BGPVPNPortAssociation) MarshalJSON() ([]byte, error) {
+	type plain BGPVPNPortAssociation
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v ConntrackHelper) MarshalJSON() ([]byte, error) {
+	type plain ConntrackHelper
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v Version) MarshalJSON() ([]byte, error) {
+	type plain Version
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v Extension) MarshalJSON() ([]byte, error) {
+	type plain Extension
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v FirewallGroup) MarshalJSON() ([]byte, error) {
+	type plain FirewallGroup
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v FirewallPolicy) MarshalJSON() ([]byte, error) {
+	type plain FirewallPolicy
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v FirewallRule) MarshalJSON() ([]byte, error) {
+	type plain FirewallRule
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v Flavor) MarshalJSON() ([]byte, error) {
+	type plain Flavor
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v ServiceProfile) MarshalJSON() ([]byte, error) {
+	type plain ServiceProfile
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v FloatingIP) MarshalJSON() ([]byte, error) {
+	type plain FloatingIP
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v NetworkIPAvailability) MarshalJSON() ([]byte, error) {
+	type plain NetworkIPAvailability
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v LocalIP) MarshalJSON() ([]byte, error) {
+	type plain LocalIP
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v LocalIPAssociation) MarshalJSON() ([]byte, error) {
+	type plain LocalIPAssociation
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v NetworkLog) MarshalJSON() ([]byte, error) {
+	type plain NetworkLog
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v LoggableResource) MarshalJSON() ([]byte, error) {
+	type plain LoggableResource
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v MeteringLabel) MarshalJSON() ([]byte, error) {
+	type plain MeteringLabel
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v MeteringLabelRule) MarshalJSON() ([]byte, error) {
+	type plain MeteringLabelRule
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v NDPProxy) MarshalJSON() ([]byte, error) {
+	type plain NDPProxy
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v Network) MarshalJSON() ([]byte, error) {
+	type plain Network
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v Port) MarshalJSON() ([]byte, error) {
+	type plain Port
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v PortBinding) MarshalJSON() ([]byte, error) {
+	type plain PortBinding
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v PortForwarding) MarshalJSON() ([]byte, error) {
+	type plain PortForwarding
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v Quota) MarshalJSON() ([]byte, error) {
+	type plain Quota
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v QuotaDetails) MarshalJSON() ([]byte, error) {
+	type plain QuotaDetails
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v Router) MarshalJSON() ([]byte, error) {
+	type plain Router
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v NetworkSegmentRange) MarshalJSON() ([]byte, error) {
+	type plain NetworkSegmentRange
+	// Default, Used and Available are read only
+	p := plain(v)
+	p.Default, p.Used, p.Available = false, nil, nil
+	return marshalExtras(p, v.Extras)
+}
+
+func (v PortPair) MarshalJSON() ([]byte, error) {
+	type plain PortPair
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v PortPairGroup) MarshalJSON() ([]byte, error) {
+	type plain PortPairGroup
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v FlowClassifier) MarshalJSON() ([]byte, error) {
+	type plain FlowClassifier
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v PortChain) MarshalJSON() ([]byte, error) {
+	type plain PortChain
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v ServiceGraph) MarshalJSON() ([]byte, error) {
+	type plain ServiceGraph
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v Subnet) MarshalJSON() ([]byte, error) {
+	type plain Subnet
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v TapService) MarshalJSON() ([]byte, error) {
+	type plain TapService
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v TapFlow) MarshalJSON() ([]byte, error) {
+	type plain TapFlow
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v IKEPolicy) MarshalJSON() ([]byte, error) {
+	type plain IKEPolicy
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v IPsecPolicy) MarshalJSON() ([]byte, error) {
+	type plain IPsecPolicy
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v VPNService) MarshalJSON() ([]byte, error) {
+	type plain VPNService
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v EndpointGroup) MarshalJSON() ([]byte, error) {
+	type plain EndpointGroup
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (v IPsecSiteConnection) MarshalJSON() ([]byte, error) {
+	type plain IPsecSiteConnection
+	return marshalExtras(plain(v), v.Extras)
+}
+
+func (u AddressGroupUpdate) MarshalJSON() ([]byte, error) {
+	type plain AddressGroupUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u AgentUpdate) MarshalJSON() ([]byte, error) {
+	type plain AgentUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u BGPSpeakerUpdate) MarshalJSON() ([]byte, error) {
+	type plain BGPSpeakerUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u BGPPeerUpdate) MarshalJSON() ([]byte, error) {
+	type plain BGPPeerUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u BGPVPNUpdate) MarshalJSON() ([]byte, error) {
+	type plain BGPVPNUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u BGPVPNRouterAssociationUpdate) MarshalJSON() ([]byte, error) {
+	type plain BGPVPNRouterAssociationUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u BGPVPNPortAssociationUpdate) MarshalJSON() ([]byte, error) {
+	type plain BGPVPNPortAssociationUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u ConntrackHelperUpdate) MarshalJSON() ([]byte, error) {
+	type plain ConntrackHelperUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u FirewallGroupUpdate) MarshalJSON() ([]byte, error) {
+	type plain FirewallGroupUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u FirewallPolicyUpdate) MarshalJSON() ([]byte, error) {
+	type plain FirewallPolicyUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u FirewallRuleUpdate) MarshalJSON() ([]byte, error) {
+	type plain FirewallRuleUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u FlavorUpdate) MarshalJSON() ([]byte, error) {
+	type plain FlavorUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u ServiceProfileUpdate) MarshalJSON() ([]byte, error) {
+	type plain ServiceProfileUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u LocalIPUpdate) MarshalJSON() ([]byte, error) {
+	type plain LocalIPUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u NetworkLogUpdate) MarshalJSON() ([]byte, error) {
+	type plain NetworkLogUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u NDPProxyUpdate) MarshalJSON() ([]byte, error) {
+	type plain NDPProxyUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u NetworkUpdate) MarshalJSON() ([]byte, error) {
+	type plain NetworkUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u SubnetUpdate) MarshalJSON() ([]byte, error) {
+	type plain SubnetUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u PortUpdate) MarshalJSON() ([]byte, error) {
+	type plain PortUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u PortForwardingUpdate) MarshalJSON() ([]byte, error) {
+	type plain PortForwardingUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u NetworkSegmentRangeUpdate) MarshalJSON() ([]byte, error) {
+	type plain NetworkSegmentRangeUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u PortPairUpdate) MarshalJSON() ([]byte, error) {
+	type plain PortPairUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u PortPairGroupUpdate) MarshalJSON() ([]byte, error) {
+	type plain PortPairGroupUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u FlowClassifierUpdate) MarshalJSON() ([]byte, error) {
+	type plain FlowClassifierUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u PortChainUpdate) MarshalJSON() ([]byte, error) {
+	type plain PortChainUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u ServiceGraphUpdate) MarshalJSON() ([]byte, error) {
+	type plain ServiceGraphUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u TapServiceUpdate) MarshalJSON() ([]byte, error) {
+	type plain TapServiceUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u TapFlowUpdate) MarshalJSON() ([]byte, error) {
+	type plain TapFlowUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u IKEPolicyUpdate) MarshalJSON() ([]byte, error) {
+	type plain IKEPolicyUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u IPsecPolicyUpdate) MarshalJSON() ([]byte, error) {
+	type plain IPsecPolicyUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u VPNServiceUpdate) MarshalJSON() ([]byte, error) {
+	type plain VPNServiceUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u EndpointGroupUpdate) MarshalJSON() ([]byte, error) {
+	type plain EndpointGroupUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
+
+func (u IPsecSiteConnectionUpdate) MarshalJSON() ([]byte, error) {
+	type plain IPsecSiteConnectionUpdate
+	return marshalExtras(plain(u), u.Extras)
+}
@@ -0,0 +1,265 @@
+package neutron_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const providerNetworkResp = `{
+  "network": {
+    "id": "net1",
+    "name": "provider-net",
+    "admin_state_up": true,
+    "provider:network_type": "vlan",
+    "provider:physical_network": "physnet1",
+    "provider:segmentation_id": 101,
+    "router:external": false,
+    "created_at": "2016-03-08T20:19:41Z"
+  }
+}`
+
+var _ = Describe("Extension attributes", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = ioutil.ReadAll(r.Body)
+			switch r.Method {
+			case http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, providerNetworkResp)
+			case http.MethodPut:
+				fmt.Fprintln(w, `{"port": {"id": "port1", "network_id": "net1", "qos_policy_id": "qos1"}}`)
+			default:
+				fmt.Fprintln(w, providerNetworkResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("keeps unknown attributes in extras", func() {
+		net, err := client.Network("net1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(net.Name).To(Equal("provider-net"))
+		Expect(net.Extras).To(HaveLen(4))
+		Expect(net.Extras.Has("created_at")).To(BeFalse())
+
+		networkType, err := net.Extras.String("provider:network_type")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(networkType).To(Equal("vlan"))
+
+		segmentationID, err := net.Extras.Int("provider:segmentation_id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(segmentationID).To(Equal(101))
+
+		external, err := net.Extras.Bool("router:external")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(external).To(BeFalse())
+	})
+
+	It("reports missing and mistyped attributes", func() {
+		net, err := client.Network("net1")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = net.Extras.String("qos_policy_id")
+		Expect(err).To(MatchError("missing attribute 'qos_policy_id'"))
+		_, err = net.Extras.Int("provider:network_type")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("sends extension attributes on create", func() {
+		net := neutron.Network{Name: "provider-net", AdminStateUp: true}
+		Expect(net.Extras.Set("provider:network_type", "vlan")).To(Succeed())
+		Expect(net.Extras.Set("provider:segmentation_id", 101)).To(Succeed())
+
+		_, err := client.CreateNetwork(net)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{
+			"network": {
+				"name": "provider-net",
+				"admin_state_up": true,
+				"provider:network_type": "vlan",
+				"provider:segmentation_id": 101
+			}
+		}`))
+	})
+
+	It("sends extension attributes on update", func() {
+		u := neutron.PortUpdate{}
+		Expect(u.Extras.Set("qos_policy_id", "qos1")).To(Succeed())
+
+		p, err := client.UpdatePort("port1", u)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"port": {"qos_policy_id": "qos1"}}`))
+
+		qos, err := p.Extras.String("qos_policy_id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(qos).To(Equal("qos1"))
+	})
+
+	It("keeps extras across a decode and encode round trip", func() {
+		var r neutron.SingleRouter
+		err := json.Unmarshal([]byte(`{"router": {"id": "r1", "admin_state_up": true, "enable_ndp_proxy": true}}`), &r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(json.Marshal(r)).To(MatchJSON(`{"router": {"id": "r1", "admin_state_up": true, "enable_ndp_proxy": true}}`))
+	})
+
+	It("gives fields precedence over extras with the same name", func() {
+		net := neutron.Network{Name: "field"}
+		net.Extras.Set("name", "extra")
+		Expect(json.Marshal(net)).To(MatchJSON(`{"name": "field", "admin_state_up": false}`))
+	})
+
+	It("never sends read only attributes kept in extras", func() {
+		var r neutron.SingleRouter
+		err := json.Unmarshal([]byte(`{"router": {"id": "r1", "admin_state_up": true, "created_at": "2016-03-08T20:19:41Z", "availability_zones": ["az1"], "l2_adjacency": true}}`), &r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.Router.Extras.Has("created_at")).To(BeTrue())
+		Expect(r.Router.AvailabilityZones).To(Equal([]string{"az1"}))
+
+		net := neutron.Network{Name: "copy"}
+		net.Extras = r.Router.Extras
+		_, err = client.CreateNetwork(net)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"network": {"name": "copy", "admin_state_up": false}}`))
+	})
+
+	Describe("every resource", func() {
+		for _, v := range resources {
+			v := v
+			It("keeps and sends the extras of "+reflect.TypeOf(v).Elem().Name(), func() {
+				err := json.Unmarshal([]byte(`{"x:custom": "value", "l2_adjacency": true}`), v)
+				Expect(err).ToNot(HaveOccurred())
+				extras := reflect.ValueOf(v).Elem().FieldByName("Extras").Interface().(neutron.Extras)
+				Expect(extras.String("x:custom")).To(Equal("value"))
+				Expect(extras.Bool("l2_adjacency")).To(BeTrue())
+
+				data, err := json.Marshal(reflect.ValueOf(v).Elem().Interface())
+				Expect(err).ToNot(HaveOccurred())
+				var all map[string]interface{}
+				Expect(json.Unmarshal(data, &all)).To(Succeed())
+				Expect(all).To(HaveKeyWithValue("x:custom", "value"))
+				Expect(all).ToNot(HaveKey("l2_adjacency"))
+			})
+		}
+
+		for _, u := range updates {
+			u := u
+			It("sends the extras of "+reflect.TypeOf(u).Name(), func() {
+				var extras neutron.Extras
+				Expect(extras.Set("x:custom", "value")).To(Succeed())
+				v := reflect.New(reflect.TypeOf(u)).Elem()
+				v.FieldByName("Extras").Set(reflect.ValueOf(extras))
+
+				Expect(json.Marshal(v.Interface())).To(MatchJSON(`{"x:custom": "value"}`))
+			})
+		}
+	})
+})
+
+// resources are the resource types with Extras, updates their update types.
+var resources = []interface{}{
+	&neutron.AddressGroup{},
+	&neutron.Agent{},
+	&neutron.AutoAllocatedTopology{},
+	&neutron.AvailabilityZone{},
+	&neutron.BGPSpeaker{},
+	&neutron.BGPPeer{},
+	&neutron.BGPVPN{},
+	&neutron.BGPVPNNetworkAssociation{},
+	&neutron.BGPVPNRouterAssociation{},
+	&neutron.BGPVPNPortAssociation{},
+	&neutron.ConntrackHelper{},
+	&neutron.Version{},
+	&neutron.Extension{},
+	&neutron.FirewallGroup{},
+	&neutron.FirewallPolicy{},
+	&neutron.FirewallRule{},
+	&neutron.Flavor{},
+	&neutron.ServiceProfile{},
+	&neutron.FloatingIP{},
+	&neutron.NetworkIPAvailability{},
+	&neutron.LocalIP{},
+	&neutron.LocalIPAssociation{},
+	&neutron.NetworkLog{},
+	&neutron.LoggableResource{},
+	&neutron.MeteringLabel{},
+	&neutron.MeteringLabelRule{},
+	&neutron.NDPProxy{},
+	&neutron.Network{},
+	&neutron.Port{},
+	&neutron.PortBinding{},
+	&neutron.PortForwarding{},
+	&neutron.Quota{},
+	&neutron.QuotaDetails{},
+	&neutron.Router{},
+	&neutron.NetworkSegmentRange{},
+	&neutron.PortPair{},
+	&neutron.PortPairGroup{},
+	&neutron.FlowClassifier{},
+	&neutron.PortChain{},
+	&neutron.ServiceGraph{},
+	&neutron.Subnet{},
+	&neutron.TapService{},
+	&neutron.TapFlow{},
+	&neutron.IKEPolicy{},
+	&neutron.IPsecPolicy{},
+	&neutron.VPNService{},
+	&neutron.EndpointGroup{},
+	&neutron.IPsecSiteConnection{},
+}
+
+var updates = []interface{}{
+	neutron.AddressGroupUpdate{},
+	neutron.AgentUpdate{},
+	neutron.BGPSpeakerUpdate{},
+	neutron.BGPPeerUpdate{},
+	neutron.BGPVPNUpdate{},
+	neutron.BGPVPNRouterAssociationUpdate{},
+	neutron.BGPVPNPortAssociationUpdate{},
+	neutron.ConntrackHelperUpdate{},
+	neutron.FirewallGroupUpdate{},
+	neutron.FirewallPolicyUpdate{},
+	neutron.FirewallRuleUpdate{},
+	neutron.FlavorUpdate{},
+	neutron.ServiceProfileUpdate{},
+	neutron.LocalIPUpdate{},
+	neutron.NetworkLogUpdate{},
+	neutron.NDPProxyUpdate{},
+	neutron.NetworkUpdate{},
+	neutron.SubnetUpdate{},
+	neutron.PortUpdate{},
+	neutron.PortForwardingUpdate{},
+	neutron.NetworkSegmentRangeUpdate{},
+	neutron.PortPairUpdate{},
+	neutron.PortPairGroupUpdate{},
+	neutron.FlowClassifierUpdate{},
+	neutron.PortChainUpdate{},
+	neutron.ServiceGraphUpdate{},
+	neutron.TapServiceUpdate{},
+	neutron.TapFlowUpdate{},
+	neutron.IKEPolicyUpdate{},
+	neutron.IPsecPolicyUpdate{},
+	neutron.VPNServiceUpdate{},
+	neutron.EndpointGroupUpdate{},
+	neutron.IPsecSiteConnectionUpdate{},
+}
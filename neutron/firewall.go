@@ -0,0 +1,484 @@
+package neutron
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type FirewallAction string
+
+const (
+	FirewallActionAllow  FirewallAction = "allow"
+	FirewallActionDeny   FirewallAction = "deny"
+	FirewallActionReject FirewallAction = "reject"
+)
+
+type FirewallProtocol string
+
+const (
+	FirewallProtocolTCP  FirewallProtocol = "tcp"
+	FirewallProtocolUDP  FirewallProtocol = "udp"
+	FirewallProtocolICMP FirewallProtocol = "icmp"
+	// FirewallProtocolAny matches any protocol; it is sent as null.
+	FirewallProtocolAny FirewallProtocol = ""
+)
+
+type FirewallGroup struct {
+	ID                      string   `json:"id,omitempty"`
+	Name                    string   `json:"name,omitempty"`
+	Description             string   `json:"description,omitempty"`
+	AdminStateUp            *bool    `json:"admin_state_up,omitempty"`
+	IngressFirewallPolicyID string   `json:"ingress_firewall_policy_id,omitempty"`
+	EgressFirewallPolicyID  string   `json:"egress_firewall_policy_id,omitempty"`
+	Ports                   []string `json:"ports,omitempty"`
+	Shared                  *bool    `json:"shared,omitempty"`
+	Status                  string   `json:"status,omitempty"`
+	TenantID                string   `json:"tenant_id,omitempty"`
+	ProjectID               string   `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetFirewallGroups struct {
+	FirewallGroups []FirewallGroup `json:"firewall_groups"`
+}
+
+type SingleFirewallGroup struct {
+	FirewallGroup FirewallGroup `json:"firewall_group"`
+}
+
+// FirewallGroupUpdate holds the firewall group attributes to change; nil
+// fields are left as is.
+type FirewallGroupUpdate struct {
+	Name                    *string   `json:"name,omitempty"`
+	Description             *string   `json:"description,omitempty"`
+	AdminStateUp            *bool     `json:"admin_state_up,omitempty"`
+	IngressFirewallPolicyID *string   `json:"ingress_firewall_policy_id,omitempty"`
+	EgressFirewallPolicyID  *string   `json:"egress_firewall_policy_id,omitempty"`
+	Ports                   *[]string `json:"ports,omitempty"`
+	Shared                  *bool     `json:"shared,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type FirewallPolicy struct {
+	ID            string   `json:"id,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	FirewallRules []string `json:"firewall_rules,omitempty"`
+	Audited       *bool    `json:"audited,omitempty"`
+	Shared        *bool    `json:"shared,omitempty"`
+	TenantID      string   `json:"tenant_id,omitempty"`
+	ProjectID     string   `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetFirewallPolicies struct {
+	FirewallPolicies []FirewallPolicy `json:"firewall_policies"`
+}
+
+type SingleFirewallPolicy struct {
+	FirewallPolicy FirewallPolicy `json:"firewall_policy"`
+}
+
+// FirewallPolicyUpdate holds the firewall policy attributes to change; nil
+// fields are left as is.
+type FirewallPolicyUpdate struct {
+	Name          *string   `json:"name,omitempty"`
+	Description   *string   `json:"description,omitempty"`
+	FirewallRules *[]string `json:"firewall_rules,omitempty"`
+	Audited       *bool     `json:"audited,omitempty"`
+	Shared        *bool     `json:"shared,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type FirewallRule struct {
+	ID                   string           `json:"id,omitempty"`
+	Name                 string           `json:"name,omitempty"`
+	Description          string           `json:"description,omitempty"`
+	Action               FirewallAction   `json:"action,omitempty"`
+	Protocol             FirewallProtocol `json:"protocol"`
+	IPVersion            int              `json:"ip_version,omitempty"`
+	SourceIPAddress      string           `json:"source_ip_address,omitempty"`
+	DestinationIPAddress string           `json:"destination_ip_address,omitempty"`
+	SourcePort           string           `json:"source_port,omitempty"`
+	DestinationPort      string           `json:"destination_port,omitempty"`
+	Enabled              *bool            `json:"enabled,omitempty"`
+	Shared               *bool            `json:"shared,omitempty"`
+	FirewallPolicyID     []string         `json:"firewall_policy_id,omitempty"`
+	TenantID             string           `json:"tenant_id,omitempty"`
+	ProjectID            string           `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetFirewallRules struct {
+	FirewallRules []FirewallRule `json:"firewall_rules"`
+}
+
+type SingleFirewallRule struct {
+	FirewallRule FirewallRule `json:"firewall_rule"`
+}
+
+// FirewallRuleUpdate holds the firewall rule attributes to change; nil fields
+// are left as is.
+type FirewallRuleUpdate struct {
+	Name                 *string           `json:"name,omitempty"`
+	Description          *string           `json:"description,omitempty"`
+	Action               *FirewallAction   `json:"action,omitempty"`
+	Protocol             *FirewallProtocol `json:"protocol,omitempty"`
+	IPVersion            *int              `json:"ip_version,omitempty"`
+	SourceIPAddress      *string           `json:"source_ip_address,omitempty"`
+	DestinationIPAddress *string           `json:"destination_ip_address,omitempty"`
+	SourcePort           *string           `json:"source_port,omitempty"`
+	DestinationPort      *string           `json:"destination_port,omitempty"`
+	Enabled              *bool             `json:"enabled,omitempty"`
+	Shared               *bool             `json:"shared,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// MarshalJSON sends FirewallProtocolAny as null, which is how Neutron
+// represents a rule matching any protocol.
+func (p FirewallProtocol) MarshalJSON() ([]byte, error) {
+	if p == FirewallProtocolAny {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(p))
+}
+
+// RuleInsertion positions a rule in a firewall policy relative to another
+// rule of the policy. With neither set the rule is inserted at the top.
+type RuleInsertion struct {
+	FirewallRuleID string `json:"firewall_rule_id"`
+	InsertBefore   string `json:"insert_before,omitempty"`
+	InsertAfter    string `json:"insert_after,omitempty"`
+}
+
+const fwaasExtension = "fwaas_v2"
+
+func (c *Client) FirewallGroups(opts ...ListOpts) ([]FirewallGroup, error) {
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetFirewallGroups
+	err := c.send(http.MethodGet, c.listURL("fwaas/firewall_groups", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.FirewallGroups, nil
+}
+
+func (c *Client) FirewallGroup(id string) (FirewallGroup, error) {
+	if id == "" {
+		return FirewallGroup{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallGroup{}, err
+	}
+
+	var r SingleFirewallGroup
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/fwaas/firewall_groups/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return FirewallGroup{}, err
+	}
+	return r.FirewallGroup, nil
+}
+
+func (c *Client) CreateFirewallGroup(g FirewallGroup) (FirewallGroup, error) {
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallGroup{}, err
+	}
+
+	var r SingleFirewallGroup
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/fwaas/firewall_groups", c.URL), SingleFirewallGroup{FirewallGroup: g}, http.StatusCreated, &r)
+	if err != nil {
+		return FirewallGroup{}, err
+	}
+	return r.FirewallGroup, nil
+}
+
+func (c *Client) UpdateFirewallGroup(id string, u FirewallGroupUpdate) (FirewallGroup, error) {
+	if id == "" {
+		return FirewallGroup{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallGroup{}, err
+	}
+
+	var r SingleFirewallGroup
+	body := map[string]FirewallGroupUpdate{"firewall_group": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/fwaas/firewall_groups/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return FirewallGroup{}, err
+	}
+	return r.FirewallGroup, nil
+}
+
+func (c *Client) DeleteFirewallGroup(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return err
+	}
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/fwaas/firewall_groups/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+// SetFirewallGroupPorts associates the firewall group with exactly the given
+// ports, replacing any previous association.
+func (c *Client) SetFirewallGroupPorts(id string, portIDs []string) (FirewallGroup, error) {
+	if portIDs == nil {
+		portIDs = []string{}
+	}
+	return c.UpdateFirewallGroup(id, FirewallGroupUpdate{Ports: &portIDs})
+}
+
+// AddFirewallGroupPorts associates the given ports with the firewall group,
+// keeping the ports already associated.
+func (c *Client) AddFirewallGroupPorts(id string, portIDs ...string) (FirewallGroup, error) {
+	g, err := c.FirewallGroup(id)
+	if err != nil {
+		return FirewallGroup{}, err
+	}
+	ports := append([]string{}, g.Ports...)
+	for _, p := range portIDs {
+		if !containsString(ports, p) {
+			ports = append(ports, p)
+		}
+	}
+	return c.SetFirewallGroupPorts(id, ports)
+}
+
+// RemoveFirewallGroupPorts dissociates the given ports from the firewall
+// group, keeping the other ports.
+func (c *Client) RemoveFirewallGroupPorts(id string, portIDs ...string) (FirewallGroup, error) {
+	g, err := c.FirewallGroup(id)
+	if err != nil {
+		return FirewallGroup{}, err
+	}
+	ports := []string{}
+	for _, p := range g.Ports {
+		if !containsString(portIDs, p) {
+			ports = append(ports, p)
+		}
+	}
+	return c.SetFirewallGroupPorts(id, ports)
+}
+
+func (c *Client) FirewallPolicies(opts ...ListOpts) ([]FirewallPolicy, error) {
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetFirewallPolicies
+	err := c.send(http.MethodGet, c.listURL("fwaas/firewall_policies", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.FirewallPolicies, nil
+}
+
+func (c *Client) FirewallPolicy(id string) (FirewallPolicy, error) {
+	if id == "" {
+		return FirewallPolicy{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallPolicy{}, err
+	}
+
+	var r SingleFirewallPolicy
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/fwaas/firewall_policies/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return FirewallPolicy{}, err
+	}
+	return r.FirewallPolicy, nil
+}
+
+func (c *Client) CreateFirewallPolicy(p FirewallPolicy) (FirewallPolicy, error) {
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallPolicy{}, err
+	}
+
+	var r SingleFirewallPolicy
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/fwaas/firewall_policies", c.URL), SingleFirewallPolicy{FirewallPolicy: p}, http.StatusCreated, &r)
+	if err != nil {
+		return FirewallPolicy{}, err
+	}
+	return r.FirewallPolicy, nil
+}
+
+func (c *Client) UpdateFirewallPolicy(id string, u FirewallPolicyUpdate) (FirewallPolicy, error) {
+	if id == "" {
+		return FirewallPolicy{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallPolicy{}, err
+	}
+
+	var r SingleFirewallPolicy
+	body := map[string]FirewallPolicyUpdate{"firewall_policy": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/fwaas/firewall_policies/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return FirewallPolicy{}, err
+	}
+	return r.FirewallPolicy, nil
+}
+
+func (c *Client) DeleteFirewallPolicy(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return err
+	}
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/fwaas/firewall_policies/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+// InsertFirewallRule inserts a rule into a policy at the position given by
+// the insertion.
+func (c *Client) InsertFirewallRule(policyID string, ins RuleInsertion) (FirewallPolicy, error) {
+	if policyID == "" {
+		return FirewallPolicy{}, fmt.Errorf("empty 'policyID' parameter")
+	}
+	if ins.FirewallRuleID == "" {
+		return FirewallPolicy{}, fmt.Errorf("missing firewall rule id")
+	}
+	if ins.InsertBefore != "" && ins.InsertAfter != "" {
+		return FirewallPolicy{}, fmt.Errorf("only one of insert_before and insert_after can be set")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallPolicy{}, err
+	}
+
+	var r FirewallPolicy
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/fwaas/firewall_policies/%s/insert_rule", c.URL, policyID), ins, http.StatusOK, &r)
+	if err != nil {
+		return FirewallPolicy{}, err
+	}
+	return r, nil
+}
+
+// InsertFirewallRuleAt inserts a rule into a policy at the zero based
+// position, appending it when position is past the last rule.
+func (c *Client) InsertFirewallRuleAt(policyID, ruleID string, position int) (FirewallPolicy, error) {
+	if position < 0 {
+		return FirewallPolicy{}, fmt.Errorf("invalid position %d", position)
+	}
+	p, err := c.FirewallPolicy(policyID)
+	if err != nil {
+		return FirewallPolicy{}, err
+	}
+
+	ins := RuleInsertion{FirewallRuleID: ruleID}
+	switch {
+	case len(p.FirewallRules) == 0:
+	case position < len(p.FirewallRules):
+		ins.InsertBefore = p.FirewallRules[position]
+	default:
+		ins.InsertAfter = p.FirewallRules[len(p.FirewallRules)-1]
+	}
+	return c.InsertFirewallRule(policyID, ins)
+}
+
+func (c *Client) RemoveFirewallRule(policyID, ruleID string) (FirewallPolicy, error) {
+	if policyID == "" {
+		return FirewallPolicy{}, fmt.Errorf("empty 'policyID' parameter")
+	}
+	if ruleID == "" {
+		return FirewallPolicy{}, fmt.Errorf("empty 'ruleID' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallPolicy{}, err
+	}
+
+	var r FirewallPolicy
+	body := map[string]string{"firewall_rule_id": ruleID}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/fwaas/firewall_policies/%s/remove_rule", c.URL, policyID), body, http.StatusOK, &r)
+	if err != nil {
+		return FirewallPolicy{}, err
+	}
+	return r, nil
+}
+
+func (c *Client) FirewallRules(opts ...ListOpts) ([]FirewallRule, error) {
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetFirewallRules
+	err := c.send(http.MethodGet, c.listURL("fwaas/firewall_rules", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.FirewallRules, nil
+}
+
+func (c *Client) FirewallRule(id string) (FirewallRule, error) {
+	if id == "" {
+		return FirewallRule{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallRule{}, err
+	}
+
+	var r SingleFirewallRule
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/fwaas/firewall_rules/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return FirewallRule{}, err
+	}
+	return r.FirewallRule, nil
+}
+
+func (c *Client) CreateFirewallRule(rule FirewallRule) (FirewallRule, error) {
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallRule{}, err
+	}
+
+	var r SingleFirewallRule
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/fwaas/firewall_rules", c.URL), SingleFirewallRule{FirewallRule: rule}, http.StatusCreated, &r)
+	if err != nil {
+		return FirewallRule{}, err
+	}
+	return r.FirewallRule, nil
+}
+
+func (c *Client) UpdateFirewallRule(id string, u FirewallRuleUpdate) (FirewallRule, error) {
+	if id == "" {
+		return FirewallRule{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return FirewallRule{}, err
+	}
+
+	var r SingleFirewallRule
+	body := map[string]FirewallRuleUpdate{"firewall_rule": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/fwaas/firewall_rules/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return FirewallRule{}, err
+	}
+	return r.FirewallRule, nil
+}
+
+func (c *Client) DeleteFirewallRule(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(fwaasExtension); err != nil {
+		return err
+	}
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/fwaas/firewall_rules/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
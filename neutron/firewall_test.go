@@ -0,0 +1,442 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const firewallGroupResp = `{
+  "firewall_group": {
+    "admin_state_up": true,
+    "description": "",
+    "egress_firewall_policy_id": null,
+    "id": "3b0ef8f4-82c7-44d4-a4fb-6177f9a21977",
+    "ingress_firewall_policy_id": "e3c78ab6-e827-4297-8d68-739063865a8b",
+    "name": "fwg1",
+    "ports": ["650bfd2f-7766-4a0d-839f-218f33e16998"],
+    "project_id": "45977fa2dbd7482098dd68d0d8970117",
+    "shared": false,
+    "status": "ACTIVE",
+    "tenant_id": "45977fa2dbd7482098dd68d0d8970117"
+  }
+}`
+
+const firewallGroupsResp = `{
+  "firewall_groups": [
+    {
+      "admin_state_up": true,
+      "id": "3b0ef8f4-82c7-44d4-a4fb-6177f9a21977",
+      "name": "fwg1",
+      "ports": [],
+      "status": "INACTIVE"
+    }
+  ]
+}`
+
+const firewallPolicyResp = `{
+  "firewall_policy": {
+    "audited": false,
+    "description": "",
+    "firewall_rules": [
+      "8722e0e0-9cc9-4490-9660-8c9a5732fbb0",
+      "a1c1b8a3-38da-46d2-9f5a-6f4c3c0b7e10"
+    ],
+    "id": "e3c78ab6-e827-4297-8d68-739063865a8b",
+    "name": "policy1",
+    "shared": false
+  }
+}`
+
+const insertRuleResp = `{
+  "audited": false,
+  "description": "",
+  "firewall_rules": [
+    "7bc34b8c-8d3b-4ada-a9c8-1f4c11c65692",
+    "8722e0e0-9cc9-4490-9660-8c9a5732fbb0",
+    "a1c1b8a3-38da-46d2-9f5a-6f4c3c0b7e10"
+  ],
+  "id": "e3c78ab6-e827-4297-8d68-739063865a8b",
+  "name": "policy1",
+  "shared": false
+}`
+
+const firewallRuleResp = `{
+  "firewall_rule": {
+    "action": "allow",
+    "description": "",
+    "destination_ip_address": null,
+    "destination_port": "80",
+    "enabled": true,
+    "firewall_policy_id": [],
+    "id": "8722e0e0-9cc9-4490-9660-8c9a5732fbb0",
+    "ip_version": 4,
+    "name": "ALLOW_HTTP",
+    "protocol": "tcp",
+    "shared": false,
+    "source_ip_address": null,
+    "source_port": null
+  }
+}`
+
+const firewallPoliciesResp = `{
+  "firewall_policies": [
+    {
+      "audited": true,
+      "description": "",
+      "firewall_rules": ["8722e0e0-9cc9-4490-9660-8c9a5732fbb0"],
+      "id": "e3c78ab6-e827-4297-8d68-739063865a8b",
+      "name": "policy1",
+      "shared": false,
+      "project_id": "45977fa2dbd7482098dd68d0d8970117"
+    }
+  ]
+}`
+
+const firewallRulesResp = `{
+  "firewall_rules": [
+    {
+      "action": "allow",
+      "destination_port": "80",
+      "enabled": true,
+      "firewall_policy_id": ["e3c78ab6-e827-4297-8d68-739063865a8b"],
+      "id": "8722e0e0-9cc9-4490-9660-8c9a5732fbb0",
+      "ip_version": 4,
+      "name": "ALLOW_HTTP",
+      "protocol": "tcp",
+      "shared": false
+    },
+    {
+      "action": "deny",
+      "enabled": false,
+      "id": "a1c1b8a3-38da-46d2-9f5a-6f4c3c0b7e10",
+      "ip_version": 6,
+      "name": "DENY_ALL",
+      "protocol": null,
+      "source_ip_address": "2001:db8::/32"
+    }
+  ]
+}`
+
+var _ = Describe("Firewall", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		method, path, body = "", "", nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			method = r.Method
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+				return
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+			}
+			switch r.URL.Path {
+			case "/v2.0/fwaas/firewall_groups":
+				if r.Method == http.MethodGet {
+					fmt.Fprintln(w, firewallGroupsResp)
+					return
+				}
+				fmt.Fprintln(w, firewallGroupResp)
+			case "/v2.0/fwaas/firewall_groups/fwg1":
+				fmt.Fprintln(w, firewallGroupResp)
+			case "/v2.0/fwaas/firewall_policies":
+				if r.Method == http.MethodGet {
+					fmt.Fprintln(w, firewallPoliciesResp)
+					return
+				}
+				fmt.Fprintln(w, firewallPolicyResp)
+			case "/v2.0/fwaas/firewall_policies/policy1":
+				fmt.Fprintln(w, firewallPolicyResp)
+			case "/v2.0/fwaas/firewall_rules":
+				if r.Method == http.MethodGet {
+					fmt.Fprintln(w, firewallRulesResp)
+					return
+				}
+				fmt.Fprintln(w, firewallRuleResp)
+			case "/v2.0/fwaas/firewall_policies/policy1/insert_rule",
+				"/v2.0/fwaas/firewall_policies/policy1/remove_rule":
+				fmt.Fprintln(w, insertRuleResp)
+			default:
+				fmt.Fprintln(w, firewallRuleResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("firewall groups", func() {
+		It("lists firewall groups", func() {
+			groups, err := client.FirewallGroups()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(groups).To(HaveLen(1))
+			Expect(groups[0].Status).To(Equal("INACTIVE"))
+		})
+
+		It("creates a firewall group", func() {
+			g, err := client.CreateFirewallGroup(neutron.FirewallGroup{
+				Name:                    "fwg1",
+				IngressFirewallPolicyID: "e3c78ab6-e827-4297-8d68-739063865a8b",
+				Ports:                   []string{"650bfd2f-7766-4a0d-839f-218f33e16998"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(body).To(MatchJSON(`{
+				"firewall_group": {
+					"name": "fwg1",
+					"ingress_firewall_policy_id": "e3c78ab6-e827-4297-8d68-739063865a8b",
+					"ports": ["650bfd2f-7766-4a0d-839f-218f33e16998"]
+				}
+			}`))
+			Expect(g.ID).To(Equal("3b0ef8f4-82c7-44d4-a4fb-6177f9a21977"))
+		})
+
+		It("creates a firewall group administratively down", func() {
+			adminStateUp, shared := false, false
+			_, err := client.CreateFirewallGroup(neutron.FirewallGroup{
+				Name:         "fwg1",
+				AdminStateUp: &adminStateUp,
+				Shared:       &shared,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"firewall_group": {"name": "fwg1", "admin_state_up": false, "shared": false}}`))
+		})
+
+		It("deletes a firewall group", func() {
+			err := client.DeleteFirewallGroup("fwg1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_groups/fwg1"))
+		})
+
+		It("adds ports to a firewall group", func() {
+			_, err := client.AddFirewallGroupPorts("fwg1", "port2", "650bfd2f-7766-4a0d-839f-218f33e16998")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(body).To(MatchJSON(`{"firewall_group": {"ports": ["650bfd2f-7766-4a0d-839f-218f33e16998", "port2"]}}`))
+		})
+
+		It("removes ports from a firewall group", func() {
+			_, err := client.RemoveFirewallGroupPorts("fwg1", "650bfd2f-7766-4a0d-839f-218f33e16998")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"firewall_group": {"ports": []}}`))
+		})
+
+		Context("when id is invalid", func() {
+			It("returns an error", func() {
+				_, err := client.FirewallGroup("")
+				Expect(err).To(MatchError("empty 'id' parameter"))
+			})
+		})
+	})
+
+	Describe("firewall policies", func() {
+		It("lists firewall policies", func() {
+			policies, err := client.FirewallPolicies(neutron.ListOpts{ProjectID: "45977fa2dbd7482098dd68d0d8970117"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodGet))
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_policies"))
+			Expect(policies).To(HaveLen(1))
+			Expect(*policies[0].Audited).To(BeTrue())
+			Expect(*policies[0].Shared).To(BeFalse())
+			Expect(policies[0].FirewallRules).To(Equal([]string{"8722e0e0-9cc9-4490-9660-8c9a5732fbb0"}))
+		})
+
+		It("shows a firewall policy", func() {
+			p, err := client.FirewallPolicy("policy1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_policies/policy1"))
+			Expect(p.FirewallRules).To(HaveLen(2))
+		})
+
+		It("creates a firewall policy", func() {
+			audited := false
+			_, err := client.CreateFirewallPolicy(neutron.FirewallPolicy{
+				Name:          "policy1",
+				FirewallRules: []string{"8722e0e0-9cc9-4490-9660-8c9a5732fbb0"},
+				Audited:       &audited,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_policies"))
+			Expect(body).To(MatchJSON(`{
+				"firewall_policy": {
+					"name": "policy1",
+					"firewall_rules": ["8722e0e0-9cc9-4490-9660-8c9a5732fbb0"],
+					"audited": false
+				}
+			}`))
+		})
+
+		It("replaces the rules of a firewall policy", func() {
+			rules := []string{}
+			_, err := client.UpdateFirewallPolicy("policy1", neutron.FirewallPolicyUpdate{FirewallRules: &rules})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_policies/policy1"))
+			Expect(body).To(MatchJSON(`{"firewall_policy": {"firewall_rules": []}}`))
+		})
+
+		It("deletes a firewall policy", func() {
+			err := client.DeleteFirewallPolicy("policy1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_policies/policy1"))
+		})
+
+		It("requires an id", func() {
+			_, err := client.FirewallPolicy("")
+			Expect(err).To(MatchError("empty 'id' parameter"))
+			err = client.DeleteFirewallPolicy("")
+			Expect(err).To(MatchError("empty 'id' parameter"))
+			Expect(method).To(BeEmpty())
+		})
+
+		It("inserts a rule before another rule", func() {
+			p, err := client.InsertFirewallRule("policy1", neutron.RuleInsertion{
+				FirewallRuleID: "7bc34b8c-8d3b-4ada-a9c8-1f4c11c65692",
+				InsertBefore:   "8722e0e0-9cc9-4490-9660-8c9a5732fbb0",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_policies/policy1/insert_rule"))
+			Expect(body).To(MatchJSON(`{
+				"firewall_rule_id": "7bc34b8c-8d3b-4ada-a9c8-1f4c11c65692",
+				"insert_before": "8722e0e0-9cc9-4490-9660-8c9a5732fbb0"
+			}`))
+			Expect(p.FirewallRules[0]).To(Equal("7bc34b8c-8d3b-4ada-a9c8-1f4c11c65692"))
+		})
+
+		It("inserts a rule at a position", func() {
+			_, err := client.InsertFirewallRuleAt("policy1", "rule3", 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"firewall_rule_id": "rule3", "insert_before": "a1c1b8a3-38da-46d2-9f5a-6f4c3c0b7e10"}`))
+		})
+
+		It("appends a rule when the position is past the end", func() {
+			_, err := client.InsertFirewallRuleAt("policy1", "rule3", 5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"firewall_rule_id": "rule3", "insert_after": "a1c1b8a3-38da-46d2-9f5a-6f4c3c0b7e10"}`))
+		})
+
+		It("rejects ambiguous insertions", func() {
+			_, err := client.InsertFirewallRule("policy1", neutron.RuleInsertion{
+				FirewallRuleID: "rule3",
+				InsertBefore:   "a",
+				InsertAfter:    "b",
+			})
+			Expect(err).To(MatchError("only one of insert_before and insert_after can be set"))
+		})
+
+		It("removes a rule", func() {
+			_, err := client.RemoveFirewallRule("policy1", "rule3")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_policies/policy1/remove_rule"))
+			Expect(body).To(MatchJSON(`{"firewall_rule_id": "rule3"}`))
+		})
+	})
+
+	Describe("firewall rules", func() {
+		It("lists firewall rules", func() {
+			rules, err := client.FirewallRules()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodGet))
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_rules"))
+			Expect(rules).To(HaveLen(2))
+			Expect(rules[0].FirewallPolicyID).To(Equal([]string{"e3c78ab6-e827-4297-8d68-739063865a8b"}))
+			Expect(*rules[0].Enabled).To(BeTrue())
+			Expect(rules[1].Action).To(Equal(neutron.FirewallActionDeny))
+			Expect(rules[1].Protocol).To(Equal(neutron.FirewallProtocolAny))
+			Expect(rules[1].IPVersion).To(Equal(6))
+			Expect(*rules[1].Enabled).To(BeFalse())
+			Expect(rules[1].Shared).To(BeNil())
+		})
+
+		It("shows a firewall rule", func() {
+			rule, err := client.FirewallRule("rule1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_rules/rule1"))
+			Expect(rule.Name).To(Equal("ALLOW_HTTP"))
+			Expect(rule.DestinationPort).To(Equal("80"))
+			Expect(rule.SourcePort).To(BeEmpty())
+		})
+
+		It("deletes a firewall rule", func() {
+			err := client.DeleteFirewallRule("rule1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_rules/rule1"))
+		})
+
+		It("creates a firewall rule", func() {
+			enabled := true
+			rule, err := client.CreateFirewallRule(neutron.FirewallRule{
+				Name:            "ALLOW_HTTP",
+				Action:          neutron.FirewallActionAllow,
+				Protocol:        neutron.FirewallProtocolTCP,
+				DestinationPort: "80",
+				Enabled:         &enabled,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"firewall_rule": {
+					"name": "ALLOW_HTTP",
+					"action": "allow",
+					"protocol": "tcp",
+					"destination_port": "80",
+					"enabled": true
+				}
+			}`))
+			Expect(rule.Action).To(Equal(neutron.FirewallActionAllow))
+			Expect(rule.Protocol).To(Equal(neutron.FirewallProtocolTCP))
+		})
+
+		It("creates a disabled firewall rule", func() {
+			enabled := false
+			_, err := client.CreateFirewallRule(neutron.FirewallRule{
+				Action:   neutron.FirewallActionDeny,
+				Protocol: neutron.FirewallProtocolUDP,
+				Enabled:  &enabled,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"firewall_rule": {"action": "deny", "protocol": "udp", "enabled": false}}`))
+		})
+
+		It("sends any protocol as null", func() {
+			_, err := client.CreateFirewallRule(neutron.FirewallRule{Action: neutron.FirewallActionDeny})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"firewall_rule": {"action": "deny", "protocol": null}}`))
+		})
+
+		It("updates a firewall rule", func() {
+			action := neutron.FirewallActionReject
+			_, err := client.UpdateFirewallRule("rule1", neutron.FirewallRuleUpdate{Action: &action})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/fwaas/firewall_rules/rule1"))
+			Expect(body).To(MatchJSON(`{"firewall_rule": {"action": "reject"}}`))
+		})
+	})
+})
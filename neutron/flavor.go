@@ -0,0 +1,249 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const FlavorServiceTypeL3Router = "L3_ROUTER_NAT"
+
+// Flavor lets users pick a backend for a service, such as routers, through
+// the service profiles associated with it.
+type Flavor struct {
+	ID              string   `json:"id,omitempty"`
+	Name            string   `json:"name,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	ServiceType     string   `json:"service_type,omitempty"`
+	Enabled         *bool    `json:"enabled,omitempty"`
+	ServiceProfiles []string `json:"service_profiles,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetFlavors struct {
+	Flavors []Flavor `json:"flavors"`
+}
+
+type SingleFlavor struct {
+	Flavor Flavor `json:"flavor"`
+}
+
+// FlavorUpdate holds the flavor attributes to change; nil fields are left as
+// is.
+type FlavorUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// ServiceProfile selects the driver implementing a flavor. Metainfo is a
+// driver specific JSON string.
+type ServiceProfile struct {
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+	Driver      string `json:"driver,omitempty"`
+	Enabled     *bool  `json:"enabled,omitempty"`
+	Metainfo    string `json:"metainfo,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetServiceProfiles struct {
+	ServiceProfiles []ServiceProfile `json:"service_profiles"`
+}
+
+type SingleServiceProfile struct {
+	ServiceProfile ServiceProfile `json:"service_profile"`
+}
+
+// ServiceProfileUpdate holds the service profile attributes to change; nil
+// fields are left as is.
+type ServiceProfileUpdate struct {
+	Description *string `json:"description,omitempty"`
+	Driver      *string `json:"driver,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+	Metainfo    *string `json:"metainfo,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+const flavorsExtension = "flavors"
+
+func (c *Client) Flavors(opts ...ListOpts) ([]Flavor, error) {
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetFlavors
+	err := c.send(http.MethodGet, c.listURL("flavors", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Flavors, nil
+}
+
+func (c *Client) Flavor(id string) (Flavor, error) {
+	if id == "" {
+		return Flavor{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return Flavor{}, err
+	}
+
+	var r SingleFlavor
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/flavors/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return Flavor{}, err
+	}
+	return r.Flavor, nil
+}
+
+func (c *Client) CreateFlavor(f Flavor) (Flavor, error) {
+	if f.ServiceType == "" {
+		return Flavor{}, fmt.Errorf("missing flavor service type")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return Flavor{}, err
+	}
+
+	var r SingleFlavor
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/flavors", c.URL), SingleFlavor{Flavor: f}, http.StatusCreated, &r)
+	if err != nil {
+		return Flavor{}, err
+	}
+	return r.Flavor, nil
+}
+
+func (c *Client) UpdateFlavor(id string, u FlavorUpdate) (Flavor, error) {
+	if id == "" {
+		return Flavor{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return Flavor{}, err
+	}
+
+	var r SingleFlavor
+	body := map[string]FlavorUpdate{"flavor": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/flavors/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return Flavor{}, err
+	}
+	return r.Flavor, nil
+}
+
+func (c *Client) DeleteFlavor(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/flavors/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) ServiceProfiles(opts ...ListOpts) ([]ServiceProfile, error) {
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetServiceProfiles
+	err := c.send(http.MethodGet, c.listURL("service_profiles", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.ServiceProfiles, nil
+}
+
+func (c *Client) ServiceProfile(id string) (ServiceProfile, error) {
+	if id == "" {
+		return ServiceProfile{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return ServiceProfile{}, err
+	}
+
+	var r SingleServiceProfile
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/service_profiles/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return ServiceProfile{}, err
+	}
+	return r.ServiceProfile, nil
+}
+
+func (c *Client) CreateServiceProfile(p ServiceProfile) (ServiceProfile, error) {
+	if p.Driver == "" && p.Metainfo == "" {
+		return ServiceProfile{}, fmt.Errorf("missing service profile driver or metainfo")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return ServiceProfile{}, err
+	}
+
+	var r SingleServiceProfile
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/service_profiles", c.URL), SingleServiceProfile{ServiceProfile: p}, http.StatusCreated, &r)
+	if err != nil {
+		return ServiceProfile{}, err
+	}
+	return r.ServiceProfile, nil
+}
+
+func (c *Client) UpdateServiceProfile(id string, u ServiceProfileUpdate) (ServiceProfile, error) {
+	if id == "" {
+		return ServiceProfile{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return ServiceProfile{}, err
+	}
+
+	var r SingleServiceProfile
+	body := map[string]ServiceProfileUpdate{"service_profile": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/service_profiles/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return ServiceProfile{}, err
+	}
+	return r.ServiceProfile, nil
+}
+
+func (c *Client) DeleteServiceProfile(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/service_profiles/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+// AssociateServiceProfile makes the profile one of the backends of the
+// flavor.
+func (c *Client) AssociateServiceProfile(flavorID, profileID string) error {
+	if flavorID == "" {
+		return fmt.Errorf("empty 'flavorID' parameter")
+	}
+	if profileID == "" {
+		return fmt.Errorf("empty 'profileID' parameter")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return err
+	}
+
+	body := SingleServiceProfile{ServiceProfile: ServiceProfile{ID: profileID}}
+	return c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/flavors/%s/service_profiles", c.URL, flavorID), body, http.StatusCreated, nil)
+}
+
+func (c *Client) DisassociateServiceProfile(flavorID, profileID string) error {
+	if flavorID == "" {
+		return fmt.Errorf("empty 'flavorID' parameter")
+	}
+	if profileID == "" {
+		return fmt.Errorf("empty 'profileID' parameter")
+	}
+	if err := c.requireExtension(flavorsExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/flavors/%s/service_profiles/%s", c.URL, flavorID, profileID), nil, http.StatusNoContent, nil)
+}
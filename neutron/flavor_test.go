@@ -0,0 +1,232 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const flavorsResp = `{
+  "flavors": [
+    {
+      "id": "f7b14d9a-b0dc-4fbe-bb14-a0f6970a69e0",
+      "name": "ha",
+      "description": "highly available routers",
+      "service_type": "L3_ROUTER_NAT",
+      "enabled": true,
+      "service_profiles": ["4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1"]
+    }
+  ]
+}`
+
+const flavorResp = `{
+  "flavor": {
+    "id": "f7b14d9a-b0dc-4fbe-bb14-a0f6970a69e0",
+    "name": "ha",
+    "service_type": "L3_ROUTER_NAT",
+    "enabled": false,
+    "service_profiles": []
+  }
+}`
+
+const serviceProfilesResp = `{
+  "service_profiles": [
+    {
+      "id": "4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1",
+      "description": "HA router driver",
+      "driver": "neutron.services.l3_router.service_providers.l3_ha.HaDriver",
+      "enabled": true,
+      "metainfo": "{}"
+    }
+  ]
+}`
+
+const serviceProfileResp = `{
+  "service_profile": {
+    "id": "4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1",
+    "driver": "neutron.services.l3_router.service_providers.l3_ha.HaDriver",
+    "enabled": false,
+    "metainfo": "{\"ha\": true}"
+  }
+}`
+
+var _ = Describe("Flavors", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		method, path, body = "", "", nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			method = r.Method
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			switch r.Method {
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body)
+			case http.MethodPut:
+				w.Write(body)
+			default:
+				switch r.URL.Path {
+				case "/v2.0/flavors":
+					fmt.Fprintln(w, flavorsResp)
+				case "/v2.0/service_profiles":
+					fmt.Fprintln(w, serviceProfilesResp)
+				case "/v2.0/service_profiles/4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1":
+					fmt.Fprintln(w, serviceProfileResp)
+				default:
+					fmt.Fprintln(w, flavorResp)
+				}
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("creates a router flavor", func() {
+		enabled := true
+		f, err := client.CreateFlavor(neutron.Flavor{
+			Name:        "ha",
+			ServiceType: neutron.FlavorServiceTypeL3Router,
+			Enabled:     &enabled,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/flavors"))
+		Expect(body).To(MatchJSON(`{"flavor": {"name": "ha", "service_type": "L3_ROUTER_NAT", "enabled": true}}`))
+		Expect(f.ServiceType).To(Equal(neutron.FlavorServiceTypeL3Router))
+	})
+
+	It("associates a service profile", func() {
+		err := client.AssociateServiceProfile("flavor1", "profile1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(path).To(Equal("/v2.0/flavors/flavor1/service_profiles"))
+		Expect(body).To(MatchJSON(`{"service_profile": {"id": "profile1"}}`))
+	})
+
+	It("disassociates a service profile", func() {
+		err := client.DisassociateServiceProfile("flavor1", "profile1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/flavors/flavor1/service_profiles/profile1"))
+	})
+
+	It("creates a router with a flavor", func() {
+		_, err := client.CreateRouter(neutron.Router{Name: "router1", AdminStateUp: true, FlavorID: "flavor1"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/routers"))
+		Expect(body).To(MatchJSON(`{"router": {"name": "router1", "admin_state_up": true, "flavor_id": "flavor1"}}`))
+	})
+
+	It("lists flavors", func() {
+		flavors, err := client.Flavors()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodGet))
+		Expect(path).To(Equal("/v2.0/flavors"))
+		Expect(flavors).To(HaveLen(1))
+		Expect(*flavors[0].Enabled).To(BeTrue())
+		Expect(flavors[0].ServiceProfiles).To(Equal([]string{"4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1"}))
+	})
+
+	It("gets a flavor", func() {
+		f, err := client.Flavor("f7b14d9a-b0dc-4fbe-bb14-a0f6970a69e0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/flavors/f7b14d9a-b0dc-4fbe-bb14-a0f6970a69e0"))
+		Expect(f.Name).To(Equal("ha"))
+		Expect(*f.Enabled).To(BeFalse())
+	})
+
+	It("disables a flavor", func() {
+		enabled := false
+		f, err := client.UpdateFlavor("f7b14d9a-b0dc-4fbe-bb14-a0f6970a69e0", neutron.FlavorUpdate{Enabled: &enabled})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/flavors/f7b14d9a-b0dc-4fbe-bb14-a0f6970a69e0"))
+		Expect(body).To(MatchJSON(`{"flavor": {"enabled": false}}`))
+		Expect(*f.Enabled).To(BeFalse())
+	})
+
+	It("deletes a flavor", func() {
+		err := client.DeleteFlavor("f7b14d9a-b0dc-4fbe-bb14-a0f6970a69e0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/flavors/f7b14d9a-b0dc-4fbe-bb14-a0f6970a69e0"))
+	})
+
+	It("lists service profiles", func() {
+		profiles, err := client.ServiceProfiles()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/service_profiles"))
+		Expect(profiles).To(HaveLen(1))
+		Expect(profiles[0].Description).To(Equal("HA router driver"))
+		Expect(profiles[0].Metainfo).To(Equal("{}"))
+	})
+
+	It("gets a service profile", func() {
+		p, err := client.ServiceProfile("4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/service_profiles/4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1"))
+		Expect(p.Driver).To(Equal("neutron.services.l3_router.service_providers.l3_ha.HaDriver"))
+		Expect(p.Metainfo).To(Equal(`{"ha": true}`))
+		Expect(*p.Enabled).To(BeFalse())
+	})
+
+	It("creates a service profile", func() {
+		_, err := client.CreateServiceProfile(neutron.ServiceProfile{
+			Driver:   "neutron.services.l3_router.service_providers.l3_ha.HaDriver",
+			Metainfo: "{}",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(path).To(Equal("/v2.0/service_profiles"))
+		Expect(body).To(MatchJSON(`{
+			"service_profile": {"driver": "neutron.services.l3_router.service_providers.l3_ha.HaDriver", "metainfo": "{}"}
+		}`))
+	})
+
+	It("updates a service profile", func() {
+		description := "HA routers on dedicated nodes"
+		_, err := client.UpdateServiceProfile("4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1", neutron.ServiceProfileUpdate{Description: &description})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/service_profiles/4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1"))
+		Expect(body).To(MatchJSON(`{"service_profile": {"description": "HA routers on dedicated nodes"}}`))
+	})
+
+	It("deletes a service profile", func() {
+		err := client.DeleteServiceProfile("4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/service_profiles/4e2e2b35-42c9-4e5b-9d6e-0c5a1d57f2a1"))
+	})
+
+	It("requires the flavor and profile of an association", func() {
+		err := client.AssociateServiceProfile("", "profile1")
+		Expect(err).To(MatchError("empty 'flavorID' parameter"))
+		err = client.DisassociateServiceProfile("flavor1", "")
+		Expect(err).To(MatchError("empty 'profileID' parameter"))
+		Expect(method).To(BeEmpty())
+	})
+})
@@ -0,0 +1,84 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type FloatingIP struct {
+	ID                string   `json:"id,omitempty"`
+	FloatingIPAddress string   `json:"floating_ip_address,omitempty"`
+	FloatingNetworkID string   `json:"floating_network_id"`
+	RouterID          string   `json:"router_id,omitempty"`
+	PortID            string   `json:"port_id,omitempty"`
+	FixedIPAddress    string   `json:"fixed_ip_address,omitempty"`
+	Status            string   `json:"status,omitempty"`
+	Description       string   `json:"description,omitempty"`
+	TenantID          string   `json:"tenant_id,omitempty"`
+	ProjectID         string   `json:"project_id,omitempty"`
+	DNSName           string   `json:"dns_name,omitempty"`
+	DNSDomain         string   `json:"dns_domain,omitempty"`
+	Tags              []string `json:"tags,omitempty"`
+
+	// RevisionNumber is read only; it is never sent to Neutron.
+	RevisionNumber int `json:"-"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetFloatingIPs struct {
+	FloatingIPs []FloatingIP `json:"floatingips"`
+}
+
+type SingleFloatingIP struct {
+	FloatingIP FloatingIP `json:"floatingip"`
+}
+
+func (c *Client) FloatingIPs(opts ...ListOpts) ([]FloatingIP, error) {
+	var r GetFloatingIPs
+	err := c.send(http.MethodGet, c.listURL("floatingips", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.FloatingIPs, nil
+}
+
+func (c *Client) FloatingIP(id string) (FloatingIP, error) {
+	if id == "" {
+		return FloatingIP{}, fmt.Errorf("empty 'id' parameter")
+	}
+
+	var r SingleFloatingIP
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/floatingips/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return FloatingIP{}, err
+	}
+	return r.FloatingIP, nil
+}
+
+func (c *Client) CreateFloatingIP(fip FloatingIP) (FloatingIP, error) {
+	if fip.DNSName != "" {
+		if err := ValidateDNSName(fip.DNSName); err != nil {
+			return FloatingIP{}, err
+		}
+	}
+	if fip.DNSDomain != "" {
+		if err := ValidateDNSDomain(fip.DNSDomain); err != nil {
+			return FloatingIP{}, err
+		}
+	}
+
+	var r SingleFloatingIP
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/floatingips", c.URL), SingleFloatingIP{FloatingIP: fip}, http.StatusCreated, &r)
+	if err != nil {
+		return FloatingIP{}, err
+	}
+	return r.FloatingIP, nil
+}
+
+func (c *Client) DeleteFloatingIP(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/floatingips/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
@@ -0,0 +1,153 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// NetworkIPAvailability reports IP usage of a network and its subnets. IP
+// counts are floats since IPv6 subnets can exceed the range of an integer.
+type NetworkIPAvailability struct {
+	NetworkID            string                 `json:"network_id"`
+	NetworkName          string                 `json:"network_name"`
+	TenantID             string                 `json:"tenant_id,omitempty"`
+	ProjectID            string                 `json:"project_id,omitempty"`
+	TotalIPs             float64                `json:"total_ips"`
+	UsedIPs              float64                `json:"used_ips"`
+	SubnetIPAvailability []SubnetIPAvailability `json:"subnet_ip_availability"`
+
+	Extras Extras `json:"-"`
+}
+
+type SubnetIPAvailability struct {
+	SubnetID   string  `json:"subnet_id"`
+	SubnetName string  `json:"subnet_name"`
+	CIDR       string  `json:"cidr"`
+	IPVersion  int     `json:"ip_version"`
+	TotalIPs   float64 `json:"total_ips"`
+	UsedIPs    float64 `json:"used_ips"`
+}
+
+type GetNetworkIPAvailabilities struct {
+	NetworkIPAvailabilities []NetworkIPAvailability `json:"network_ip_availabilities"`
+}
+
+type SingleNetworkIPAvailability struct {
+	NetworkIPAvailability NetworkIPAvailability `json:"network_ip_availability"`
+}
+
+// Utilization returns the percentage of used IPs in the network.
+func (a NetworkIPAvailability) Utilization() float64 {
+	return utilization(a.UsedIPs, a.TotalIPs)
+}
+
+// Utilization returns the percentage of used IPs in the subnet.
+func (a SubnetIPAvailability) Utilization() float64 {
+	return utilization(a.UsedIPs, a.TotalIPs)
+}
+
+func utilization(used, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return used / total * 100
+}
+
+func (c *Client) NetworkIPAvailabilities(opts ...ListOpts) ([]NetworkIPAvailability, error) {
+	if err := c.requireExtension("network-ip-availability"); err != nil {
+		return nil, err
+	}
+
+	var r GetNetworkIPAvailabilities
+	err := c.send(http.MethodGet, c.listURL("network-ip-availabilities", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.NetworkIPAvailabilities, nil
+}
+
+func (c *Client) NetworkIPAvailability(networkID string) (NetworkIPAvailability, error) {
+	if networkID == "" {
+		return NetworkIPAvailability{}, fmt.Errorf("empty 'networkID' parameter")
+	}
+	if err := c.requireExtension("network-ip-availability"); err != nil {
+		return NetworkIPAvailability{}, err
+	}
+
+	var r SingleNetworkIPAvailability
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/network-ip-availabilities/%s", c.URL, networkID), nil, http.StatusOK, &r)
+	if err != nil {
+		return NetworkIPAvailability{}, err
+	}
+	return r.NetworkIPAvailability, nil
+}
+
+type SubnetUtilization struct {
+	NetworkID      string
+	NetworkName    string
+	SubnetID       string
+	SubnetName     string
+	CIDR           string
+	TotalIPs       float64
+	UsedIPs        float64
+	Utilization    float64
+	AboveThreshold bool
+}
+
+type IPUtilizationReport struct {
+	Threshold float64
+	Subnets   []SubnetUtilization
+}
+
+// Flagged returns the subnets whose utilization is above the report threshold.
+func (r IPUtilizationReport) Flagged() []SubnetUtilization {
+	var flagged []SubnetUtilization
+	for _, s := range r.Subnets {
+		if s.AboveThreshold {
+			flagged = append(flagged, s)
+		}
+	}
+	return flagged
+}
+
+// IPUtilizationReport computes the utilization of every subnet of the
+// networks returned by Networks, flagging those above threshold percent.
+func (c *Client) IPUtilizationReport(threshold float64, opts ...ListOpts) (IPUtilizationReport, error) {
+	networks, err := c.Networks(opts...)
+	if err != nil {
+		return IPUtilizationReport{}, err
+	}
+
+	availabilities, err := c.NetworkIPAvailabilities()
+	if err != nil {
+		return IPUtilizationReport{}, err
+	}
+
+	byNetwork := make(map[string]NetworkIPAvailability, len(availabilities))
+	for _, a := range availabilities {
+		byNetwork[a.NetworkID] = a
+	}
+
+	report := IPUtilizationReport{Threshold: threshold}
+	for _, n := range networks {
+		a, ok := byNetwork[n.ID]
+		if !ok {
+			continue
+		}
+		for _, s := range a.SubnetIPAvailability {
+			u := s.Utilization()
+			report.Subnets = append(report.Subnets, SubnetUtilization{
+				NetworkID:      n.ID,
+				NetworkName:    n.Name,
+				SubnetID:       s.SubnetID,
+				SubnetName:     s.SubnetName,
+				CIDR:           s.CIDR,
+				TotalIPs:       s.TotalIPs,
+				UsedIPs:        s.UsedIPs,
+				Utilization:    u,
+				AboveThreshold: u > threshold,
+			})
+		}
+	}
+	return report, nil
+}
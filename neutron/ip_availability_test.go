@@ -0,0 +1,158 @@
+package neutron_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const networkIPAvailabilitiesResp = `{
+  "network_ip_availabilities": [
+    {
+      "network_id": "e53a3b67-0074-404c-90b5-52ae217c3587",
+      "network_name": "public",
+      "project_id": "1f77bad08b454898803a3d9f9e3799ec",
+      "tenant_id": "1f77bad08b454898803a3d9f9e3799ec",
+      "total_ips": 253,
+      "used_ips": 201,
+      "subnet_ip_availability": [
+        {
+          "cidr": "172.24.4.0/24",
+          "ip_version": 4,
+          "subnet_id": "3cc622c0-1ed8-470b-8d2b-081305df63b5",
+          "subnet_name": "public-subnet",
+          "total_ips": 200,
+          "used_ips": 190
+        },
+        {
+          "cidr": "2001:db8::/64",
+          "ip_version": 6,
+          "subnet_id": "9081fc4f-2415-4d99-ae99-0abb262ada90",
+          "subnet_name": "ipv6-public-subnet",
+          "total_ips": 18446744073709551614,
+          "used_ips": 11
+        }
+      ]
+    },
+    {
+      "network_id": "4cf895c9-c3d1-489e-b02e-59b5c8976809",
+      "network_name": "private",
+      "total_ips": 10,
+      "used_ips": 1,
+      "subnet_ip_availability": [
+        {
+          "cidr": "10.0.0.0/28",
+          "ip_version": 4,
+          "subnet_id": "6e1c1ab1-f80d-4ef4-8b2d-18bc2a1bbe8a",
+          "subnet_name": "private-subnet",
+          "total_ips": 10,
+          "used_ips": 1
+        }
+      ]
+    }
+  ]
+}`
+
+const networkIPAvailabilityResp = `{
+  "network_ip_availability": {
+    "network_id": "4cf895c9-c3d1-489e-b02e-59b5c8976809",
+    "network_name": "private",
+    "total_ips": 10,
+    "used_ips": 5,
+    "subnet_ip_availability": []
+  }
+}`
+
+var _ = Describe("Network IP availability", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		query  string
+	)
+
+	BeforeEach(func() {
+		query = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			switch r.URL.Path {
+			case "/v2.0/extensions":
+				fmt.Fprintln(w, extensionsResp)
+			case "/v2.0/networks":
+				fmt.Fprintln(w, networks)
+			case "/v2.0/network-ip-availabilities":
+				fmt.Fprintln(w, networkIPAvailabilitiesResp)
+			default:
+				fmt.Fprintln(w, networkIPAvailabilityResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NetworkIPAvailabilities", func() {
+		It("lists IP availability of networks and subnets", func() {
+			avail, err := client.NetworkIPAvailabilities()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(avail).To(HaveLen(2))
+			Expect(avail[0].NetworkName).To(Equal("public"))
+			Expect(avail[0].TotalIPs).To(Equal(253.0))
+			Expect(avail[0].SubnetIPAvailability).To(HaveLen(2))
+			Expect(avail[0].SubnetIPAvailability[0].UsedIPs).To(Equal(190.0))
+			Expect(avail[0].SubnetIPAvailability[0].Utilization()).To(Equal(95.0))
+		})
+
+		It("filters by project", func() {
+			_, err := client.NetworkIPAvailabilities(neutron.ListOpts{ProjectID: "d4bf6ac7bb4c4bf4b0f1b6a8d6b1e8f4"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(query).To(Equal("project_id=d4bf6ac7bb4c4bf4b0f1b6a8d6b1e8f4"))
+		})
+	})
+
+	Describe("NetworkIPAvailability", func() {
+		It("gets IP availability of a network", func() {
+			avail, err := client.NetworkIPAvailability("4cf895c9-c3d1-489e-b02e-59b5c8976809")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(avail.NetworkName).To(Equal("private"))
+			Expect(avail.Utilization()).To(Equal(50.0))
+		})
+
+		Context("when network id is invalid", func() {
+			It("returns an error", func() {
+				_, err := client.NetworkIPAvailability("")
+				Expect(err).To(MatchError("empty 'networkID' parameter"))
+			})
+		})
+	})
+
+	Describe("IPUtilizationReport", func() {
+		It("reports utilization of subnets of listed networks", func() {
+			report, err := client.IPUtilizationReport(80)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(report.Threshold).To(Equal(80.0))
+			Expect(report.Subnets).To(HaveLen(2))
+			Expect(report.Subnets[0].NetworkName).To(Equal("public"))
+			Expect(report.Subnets[0].SubnetName).To(Equal("public-subnet"))
+			Expect(report.Subnets[0].Utilization).To(Equal(95.0))
+			Expect(report.Subnets[1].CIDR).To(Equal("2001:db8::/64"))
+			Expect(report.Subnets[1].Utilization).To(BeNumerically("<", 1))
+		})
+
+		It("flags subnets above the threshold", func() {
+			report, err := client.IPUtilizationReport(80)
+			Expect(err).ToNot(HaveOccurred())
+			flagged := report.Flagged()
+			Expect(flagged).To(HaveLen(1))
+			Expect(flagged[0].SubnetID).To(Equal("3cc622c0-1ed8-470b-8d2b-081305df63b5"))
+		})
+	})
+})
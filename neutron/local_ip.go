@@ -0,0 +1,190 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	LocalIPModeTranslate   = "translate"
+	LocalIPModePassthrough = "passthrough"
+)
+
+// LocalIP is a virtual IP reachable from ports on the same host without
+// leaving it. It is backed by LocalPortID, or by a port Neutron creates on
+// NetworkID.
+type LocalIP struct {
+	ID             string `json:"id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Description    string `json:"description,omitempty"`
+	LocalPortID    string `json:"local_port_id,omitempty"`
+	NetworkID      string `json:"network_id,omitempty"`
+	LocalIPAddress string `json:"local_ip_address,omitempty"`
+	IPMode         string `json:"ip_mode,omitempty"`
+	TenantID       string `json:"tenant_id,omitempty"`
+	ProjectID      string `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetLocalIPs struct {
+	LocalIPs []LocalIP `json:"local_ips"`
+}
+
+type SingleLocalIP struct {
+	LocalIP LocalIP `json:"local_ip"`
+}
+
+// LocalIPUpdate holds the local IP attributes to change; nil fields are left
+// as is.
+type LocalIPUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// LocalIPAssociation binds a local IP to a fixed IP of a port. FixedIP may be
+// left empty when the port has a single fixed IP.
+type LocalIPAssociation struct {
+	LocalIPID      string `json:"local_ip_id,omitempty"`
+	LocalIPAddress string `json:"local_ip_address,omitempty"`
+	FixedPortID    string `json:"fixed_port_id"`
+	FixedIP        string `json:"fixed_ip,omitempty"`
+	Host           string `json:"host,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetLocalIPAssociations struct {
+	LocalIPAssociations []LocalIPAssociation `json:"port_associations"`
+}
+
+type SingleLocalIPAssociation struct {
+	LocalIPAssociation LocalIPAssociation `json:"port_association"`
+}
+
+const localIPExtension = "local_ip"
+
+func (c *Client) LocalIPs(opts ...ListOpts) ([]LocalIP, error) {
+	if err := c.requireExtension(localIPExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetLocalIPs
+	err := c.send(http.MethodGet, c.listURL("local_ips", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.LocalIPs, nil
+}
+
+func (c *Client) LocalIP(id string) (LocalIP, error) {
+	if id == "" {
+		return LocalIP{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(localIPExtension); err != nil {
+		return LocalIP{}, err
+	}
+
+	var r SingleLocalIP
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/local_ips/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return LocalIP{}, err
+	}
+	return r.LocalIP, nil
+}
+
+func (c *Client) CreateLocalIP(ip LocalIP) (LocalIP, error) {
+	if ip.LocalPortID == "" && ip.NetworkID == "" {
+		return LocalIP{}, fmt.Errorf("missing local port id or network id")
+	}
+	if err := c.requireExtension(localIPExtension); err != nil {
+		return LocalIP{}, err
+	}
+
+	var r SingleLocalIP
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/local_ips", c.URL), SingleLocalIP{LocalIP: ip}, http.StatusCreated, &r)
+	if err != nil {
+		return LocalIP{}, err
+	}
+	return r.LocalIP, nil
+}
+
+func (c *Client) UpdateLocalIP(id string, u LocalIPUpdate) (LocalIP, error) {
+	if id == "" {
+		return LocalIP{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(localIPExtension); err != nil {
+		return LocalIP{}, err
+	}
+
+	var r SingleLocalIP
+	body := map[string]LocalIPUpdate{"local_ip": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/local_ips/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return LocalIP{}, err
+	}
+	return r.LocalIP, nil
+}
+
+func (c *Client) DeleteLocalIP(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(localIPExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/local_ips/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) LocalIPAssociations(localIPID string) ([]LocalIPAssociation, error) {
+	if localIPID == "" {
+		return nil, fmt.Errorf("empty 'localIPID' parameter")
+	}
+	if err := c.requireExtension(localIPExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetLocalIPAssociations
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/local_ips/%s/port_associations", c.URL, localIPID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.LocalIPAssociations, nil
+}
+
+func (c *Client) CreateLocalIPAssociation(localIPID, fixedPortID, fixedIP string) (LocalIPAssociation, error) {
+	if localIPID == "" {
+		return LocalIPAssociation{}, fmt.Errorf("empty 'localIPID' parameter")
+	}
+	if fixedPortID == "" {
+		return LocalIPAssociation{}, fmt.Errorf("empty 'fixedPortID' parameter")
+	}
+	if err := c.requireExtension(localIPExtension); err != nil {
+		return LocalIPAssociation{}, err
+	}
+
+	var r SingleLocalIPAssociation
+	body := SingleLocalIPAssociation{LocalIPAssociation: LocalIPAssociation{FixedPortID: fixedPortID, FixedIP: fixedIP}}
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/local_ips/%s/port_associations", c.URL, localIPID), body, http.StatusCreated, &r)
+	if err != nil {
+		return LocalIPAssociation{}, err
+	}
+	return r.LocalIPAssociation, nil
+}
+
+func (c *Client) DeleteLocalIPAssociation(localIPID, fixedPortID string) error {
+	if localIPID == "" {
+		return fmt.Errorf("empty 'localIPID' parameter")
+	}
+	if fixedPortID == "" {
+		return fmt.Errorf("empty 'fixedPortID' parameter")
+	}
+	if err := c.requireExtension(localIPExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/local_ips/%s/port_associations/%s", c.URL, localIPID, fixedPortID), nil, http.StatusNoContent, nil)
+}
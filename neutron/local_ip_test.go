@@ -0,0 +1,202 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const localIPAssociationResp = `{
+  "port_association": {
+    "local_ip_id": "3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60",
+    "local_ip_address": "172.24.4.100",
+    "fixed_port_id": "8c4b3b9a-7d5e-4e8f-9a0b-1c2d3e4f5a6b",
+    "fixed_ip": "10.0.0.5",
+    "host": "compute-1"
+  }
+}`
+
+const localIPsResp = `{
+  "local_ips": [
+    {
+      "id": "3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60",
+      "name": "cache",
+      "local_port_id": "a5d3c9e1-2f4b-4c6d-8e0f-1a2b3c4d5e6f",
+      "network_id": "6f1b9c1c-4b1f-4a38-9a3e-2a8f0c6a3d11",
+      "local_ip_address": "172.24.4.100",
+      "ip_mode": "translate"
+    }
+  ]
+}`
+
+const localIPResp = `{
+  "local_ip": {
+    "id": "3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60",
+    "name": "cache",
+    "description": "memcached",
+    "local_port_id": "a5d3c9e1-2f4b-4c6d-8e0f-1a2b3c4d5e6f",
+    "local_ip_address": "172.24.4.100",
+    "ip_mode": "passthrough"
+  }
+}`
+
+const localIPAssociationsResp = `{
+  "port_associations": [
+    {
+      "local_ip_id": "3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60",
+      "local_ip_address": "172.24.4.100",
+      "fixed_port_id": "8c4b3b9a-7d5e-4e8f-9a0b-1c2d3e4f5a6b",
+      "fixed_ip": "10.0.0.5",
+      "host": "compute-1"
+    }
+  ]
+}`
+
+var _ = Describe("Local IPs", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		method   string
+		path     string
+		body     []byte
+		requests int
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		method, path, body = "", "", nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests++
+			method = r.Method
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/port_associations"):
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, localIPAssociationResp)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, localIPResp)
+			case r.Method == http.MethodPut:
+				w.Write(body)
+			case r.URL.Path == "/v2.0/local_ips":
+				fmt.Fprintln(w, localIPsResp)
+			case strings.HasSuffix(r.URL.Path, "/port_associations"):
+				fmt.Fprintln(w, localIPAssociationsResp)
+			default:
+				fmt.Fprintln(w, localIPResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("requires a port or network for new local IPs", func() {
+		_, err := client.CreateLocalIP(neutron.LocalIP{Name: "cache"})
+		Expect(err).To(MatchError("missing local port id or network id"))
+		Expect(requests).To(Equal(0))
+	})
+
+	It("associates a local IP with a fixed port", func() {
+		a, err := client.CreateLocalIPAssociation("3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60", "8c4b3b9a-7d5e-4e8f-9a0b-1c2d3e4f5a6b", "10.0.0.5")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(path).To(Equal("/v2.0/local_ips/3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60/port_associations"))
+		Expect(body).To(MatchJSON(`{
+			"port_association": {"fixed_port_id": "8c4b3b9a-7d5e-4e8f-9a0b-1c2d3e4f5a6b", "fixed_ip": "10.0.0.5"}
+		}`))
+		Expect(a.LocalIPAddress).To(Equal("172.24.4.100"))
+		Expect(a.Host).To(Equal("compute-1"))
+	})
+
+	It("removes a port association", func() {
+		err := client.DeleteLocalIPAssociation("3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60", "8c4b3b9a-7d5e-4e8f-9a0b-1c2d3e4f5a6b")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/local_ips/3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60/port_associations/8c4b3b9a-7d5e-4e8f-9a0b-1c2d3e4f5a6b"))
+	})
+
+	It("lists local IPs", func() {
+		ips, err := client.LocalIPs()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodGet))
+		Expect(path).To(Equal("/v2.0/local_ips"))
+		Expect(ips).To(HaveLen(1))
+		Expect(ips[0].NetworkID).To(Equal("6f1b9c1c-4b1f-4a38-9a3e-2a8f0c6a3d11"))
+		Expect(ips[0].IPMode).To(Equal(neutron.LocalIPModeTranslate))
+	})
+
+	It("gets a local IP", func() {
+		ip, err := client.LocalIP("3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/local_ips/3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60"))
+		Expect(ip.Description).To(Equal("memcached"))
+		Expect(ip.IPMode).To(Equal(neutron.LocalIPModePassthrough))
+	})
+
+	It("creates a local IP on a port", func() {
+		ip, err := client.CreateLocalIP(neutron.LocalIP{
+			Name:        "cache",
+			LocalPortID: "a5d3c9e1-2f4b-4c6d-8e0f-1a2b3c4d5e6f",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(path).To(Equal("/v2.0/local_ips"))
+		Expect(body).To(MatchJSON(`{
+			"local_ip": {"name": "cache", "local_port_id": "a5d3c9e1-2f4b-4c6d-8e0f-1a2b3c4d5e6f"}
+		}`))
+		Expect(ip.LocalIPAddress).To(Equal("172.24.4.100"))
+	})
+
+	It("updates a local IP", func() {
+		name := "cache-v2"
+		ip, err := client.UpdateLocalIP("3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60", neutron.LocalIPUpdate{Name: &name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/local_ips/3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60"))
+		Expect(body).To(MatchJSON(`{"local_ip": {"name": "cache-v2"}}`))
+		Expect(ip.Name).To(Equal("cache-v2"))
+	})
+
+	It("deletes a local IP", func() {
+		err := client.DeleteLocalIP("3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/local_ips/3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60"))
+	})
+
+	It("lists the port associations of a local IP", func() {
+		as, err := client.LocalIPAssociations("3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/local_ips/3a4c0a2e-6a5c-4d3b-8f1e-1b2c3d4e5f60/port_associations"))
+		Expect(as).To(HaveLen(1))
+		Expect(as[0].FixedPortID).To(Equal("8c4b3b9a-7d5e-4e8f-9a0b-1c2d3e4f5a6b"))
+		Expect(as[0].FixedIP).To(Equal("10.0.0.5"))
+	})
+
+	It("requires an id", func() {
+		_, err := client.LocalIP("")
+		Expect(err).To(MatchError("empty 'id' parameter"))
+		_, err = client.UpdateLocalIP("", neutron.LocalIPUpdate{})
+		Expect(err).To(MatchError("empty 'id' parameter"))
+		Expect(requests).To(Equal(0))
+	})
+})
@@ -0,0 +1,166 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type LogEvent string
+
+const (
+	LogEventAll    LogEvent = "ALL"
+	LogEventAccept LogEvent = "ACCEPT"
+	LogEventDrop   LogEvent = "DROP"
+)
+
+const (
+	LogResourceSecurityGroup = "security_group"
+	LogResourceFirewallGroup = "firewall_group"
+)
+
+// NetworkLog logs the packets of a security group or firewall group.
+// ResourceID limits logging to one group and TargetID to one port; with
+// neither set, all groups of ResourceType in the project are logged.
+type NetworkLog struct {
+	ID           string   `json:"id,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	ResourceType string   `json:"resource_type,omitempty"`
+	ResourceID   string   `json:"resource_id,omitempty"`
+	TargetID     string   `json:"target_id,omitempty"`
+	Event        LogEvent `json:"event,omitempty"`
+	Enabled      *bool    `json:"enabled,omitempty"`
+	TenantID     string   `json:"tenant_id,omitempty"`
+	ProjectID    string   `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetNetworkLogs struct {
+	NetworkLogs []NetworkLog `json:"logs"`
+}
+
+type SingleNetworkLog struct {
+	NetworkLog NetworkLog `json:"log"`
+}
+
+// NetworkLogUpdate holds the log attributes to change; nil fields are left
+// as is.
+type NetworkLogUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// LoggableResource is a resource type that logs can be created for.
+type LoggableResource struct {
+	Type string `json:"type"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetLoggableResources struct {
+	LoggableResources []LoggableResource `json:"loggable_resources"`
+}
+
+const loggingExtension = "logging"
+
+func validateLogEvent(e LogEvent) error {
+	switch e {
+	case "", LogEventAll, LogEventAccept, LogEventDrop:
+		return nil
+	}
+	return fmt.Errorf("invalid log event '%s'", e)
+}
+
+// LoggableResources returns the resource types the deployment can log.
+func (c *Client) LoggableResources() ([]LoggableResource, error) {
+	if err := c.requireExtension(loggingExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetLoggableResources
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/log/loggable-resources", c.URL), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.LoggableResources, nil
+}
+
+func (c *Client) NetworkLogs(opts ...ListOpts) ([]NetworkLog, error) {
+	if err := c.requireExtension(loggingExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetNetworkLogs
+	err := c.send(http.MethodGet, c.listURL("log/logs", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.NetworkLogs, nil
+}
+
+func (c *Client) NetworkLog(id string) (NetworkLog, error) {
+	if id == "" {
+		return NetworkLog{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(loggingExtension); err != nil {
+		return NetworkLog{}, err
+	}
+
+	var r SingleNetworkLog
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/log/logs/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return NetworkLog{}, err
+	}
+	return r.NetworkLog, nil
+}
+
+func (c *Client) CreateNetworkLog(l NetworkLog) (NetworkLog, error) {
+	if l.ResourceType == "" {
+		return NetworkLog{}, fmt.Errorf("missing log resource type")
+	}
+	if err := validateLogEvent(l.Event); err != nil {
+		return NetworkLog{}, err
+	}
+	if err := c.requireExtension(loggingExtension); err != nil {
+		return NetworkLog{}, err
+	}
+
+	var r SingleNetworkLog
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/log/logs", c.URL), SingleNetworkLog{NetworkLog: l}, http.StatusCreated, &r)
+	if err != nil {
+		return NetworkLog{}, err
+	}
+	return r.NetworkLog, nil
+}
+
+func (c *Client) UpdateNetworkLog(id string, u NetworkLogUpdate) (NetworkLog, error) {
+	if id == "" {
+		return NetworkLog{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(loggingExtension); err != nil {
+		return NetworkLog{}, err
+	}
+
+	var r SingleNetworkLog
+	body := map[string]NetworkLogUpdate{"log": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/log/logs/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return NetworkLog{}, err
+	}
+	return r.NetworkLog, nil
+}
+
+func (c *Client) DeleteNetworkLog(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(loggingExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/log/logs/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
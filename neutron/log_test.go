@@ -0,0 +1,136 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const networkLogResp = `{
+  "log": {
+    "id": "2f245a7b-796b-4f26-9cf9-9e82d248fda7",
+    "project_id": "92a5a4f4245a4abbafacb7ca73b027b0",
+    "name": "audit-web",
+    "description": "",
+    "resource_type": "security_group",
+    "resource_id": "85e4ee7b-c6b4-4a2d-a2a4-7e9b5e83c8d5",
+    "target_id": "0f1d59f5-51a7-4ec4-8a44-7e0b5c30b52a",
+    "event": "DROP",
+    "enabled": true,
+    "revision_number": 1
+  }
+}`
+
+const loggableResourcesResp = `{
+  "loggable_resources": [
+    {"type": "security_group"},
+    {"type": "firewall_group"}
+  ]
+}`
+
+var _ = Describe("Logging", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		method   string
+		path     string
+		body     []byte
+		requests int
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests++
+			method = r.Method
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.URL.Path == "/v2.0/log/loggable-resources":
+				fmt.Fprintln(w, loggableResourcesResp)
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, networkLogResp)
+			default:
+				fmt.Fprintln(w, networkLogResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists loggable resources", func() {
+		resources, err := client.LoggableResources()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resources).To(Equal([]neutron.LoggableResource{
+			{Type: neutron.LogResourceSecurityGroup},
+			{Type: neutron.LogResourceFirewallGroup},
+		}))
+	})
+
+	It("creates a security group log for a port", func() {
+		l, err := client.CreateNetworkLog(neutron.NetworkLog{
+			Name:         "audit-web",
+			ResourceType: neutron.LogResourceSecurityGroup,
+			ResourceID:   "85e4ee7b-c6b4-4a2d-a2a4-7e9b5e83c8d5",
+			TargetID:     "0f1d59f5-51a7-4ec4-8a44-7e0b5c30b52a",
+			Event:        neutron.LogEventDrop,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(path).To(Equal("/v2.0/log/logs"))
+		Expect(body).To(MatchJSON(`{
+			"log": {
+				"name": "audit-web",
+				"resource_type": "security_group",
+				"resource_id": "85e4ee7b-c6b4-4a2d-a2a4-7e9b5e83c8d5",
+				"target_id": "0f1d59f5-51a7-4ec4-8a44-7e0b5c30b52a",
+				"event": "DROP"
+			}
+		}`))
+		Expect(l.ID).To(Equal("2f245a7b-796b-4f26-9cf9-9e82d248fda7"))
+		Expect(l.Event).To(Equal(neutron.LogEventDrop))
+		Expect(*l.Enabled).To(BeTrue())
+	})
+
+	It("rejects invalid events", func() {
+		_, err := client.CreateNetworkLog(neutron.NetworkLog{
+			ResourceType: neutron.LogResourceSecurityGroup,
+			Event:        "REJECT",
+		})
+		Expect(err).To(MatchError("invalid log event 'REJECT'"))
+		Expect(requests).To(Equal(0))
+	})
+
+	It("disables a log", func() {
+		enabled := false
+		_, err := client.UpdateNetworkLog("2f245a7b-796b-4f26-9cf9-9e82d248fda7", neutron.NetworkLogUpdate{Enabled: &enabled})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/log/logs/2f245a7b-796b-4f26-9cf9-9e82d248fda7"))
+		Expect(body).To(MatchJSON(`{"log": {"enabled": false}}`))
+	})
+
+	It("deletes a log", func() {
+		err := client.DeleteNetworkLog("2f245a7b-796b-4f26-9cf9-9e82d248fda7")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+	})
+})
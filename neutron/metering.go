@@ -0,0 +1,172 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type MeteringDirection string
+
+const (
+	MeteringDirectionIngress MeteringDirection = "ingress"
+	MeteringDirectionEgress  MeteringDirection = "egress"
+)
+
+// MeteringLabel counts the traffic of the routers of its project, or of all
+// projects when shared.
+type MeteringLabel struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Shared      bool   `json:"shared,omitempty"`
+	TenantID    string `json:"tenant_id,omitempty"`
+	ProjectID   string `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetMeteringLabels struct {
+	MeteringLabels []MeteringLabel `json:"metering_labels"`
+}
+
+type SingleMeteringLabel struct {
+	MeteringLabel MeteringLabel `json:"metering_label"`
+}
+
+// MeteringLabelRule selects the traffic counted by a label. RemoteIPPrefix is
+// deprecated in favour of SourceIPPrefix and DestinationIPPrefix.
+type MeteringLabelRule struct {
+	ID                  string            `json:"id,omitempty"`
+	MeteringLabelID     string            `json:"metering_label_id"`
+	Direction           MeteringDirection `json:"direction,omitempty"`
+	RemoteIPPrefix      string            `json:"remote_ip_prefix,omitempty"`
+	SourceIPPrefix      string            `json:"source_ip_prefix,omitempty"`
+	DestinationIPPrefix string            `json:"destination_ip_prefix,omitempty"`
+	Excluded            bool              `json:"excluded,omitempty"`
+	TenantID            string            `json:"tenant_id,omitempty"`
+	ProjectID           string            `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetMeteringLabelRules struct {
+	MeteringLabelRules []MeteringLabelRule `json:"metering_label_rules"`
+}
+
+type SingleMeteringLabelRule struct {
+	MeteringLabelRule MeteringLabelRule `json:"metering_label_rule"`
+}
+
+const meteringExtension = "metering"
+
+func (c *Client) MeteringLabels(opts ...ListOpts) ([]MeteringLabel, error) {
+	if err := c.requireExtension(meteringExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetMeteringLabels
+	err := c.send(http.MethodGet, c.listURL("metering/metering-labels", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.MeteringLabels, nil
+}
+
+func (c *Client) MeteringLabel(id string) (MeteringLabel, error) {
+	if id == "" {
+		return MeteringLabel{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(meteringExtension); err != nil {
+		return MeteringLabel{}, err
+	}
+
+	var r SingleMeteringLabel
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/metering/metering-labels/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return MeteringLabel{}, err
+	}
+	return r.MeteringLabel, nil
+}
+
+func (c *Client) CreateMeteringLabel(l MeteringLabel) (MeteringLabel, error) {
+	if err := c.requireExtension(meteringExtension); err != nil {
+		return MeteringLabel{}, err
+	}
+
+	var r SingleMeteringLabel
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/metering/metering-labels", c.URL), SingleMeteringLabel{MeteringLabel: l}, http.StatusCreated, &r)
+	if err != nil {
+		return MeteringLabel{}, err
+	}
+	return r.MeteringLabel, nil
+}
+
+func (c *Client) DeleteMeteringLabel(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(meteringExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/metering/metering-labels/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) MeteringLabelRules(opts ...ListOpts) ([]MeteringLabelRule, error) {
+	if err := c.requireExtension(meteringExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetMeteringLabelRules
+	err := c.send(http.MethodGet, c.listURL("metering/metering-label-rules", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.MeteringLabelRules, nil
+}
+
+func (c *Client) MeteringLabelRule(id string) (MeteringLabelRule, error) {
+	if id == "" {
+		return MeteringLabelRule{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(meteringExtension); err != nil {
+		return MeteringLabelRule{}, err
+	}
+
+	var r SingleMeteringLabelRule
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/metering/metering-label-rules/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return MeteringLabelRule{}, err
+	}
+	return r.MeteringLabelRule, nil
+}
+
+func (c *Client) CreateMeteringLabelRule(rule MeteringLabelRule) (MeteringLabelRule, error) {
+	if rule.MeteringLabelID == "" {
+		return MeteringLabelRule{}, fmt.Errorf("missing metering label id")
+	}
+	if rule.RemoteIPPrefix != "" && (rule.SourceIPPrefix != "" || rule.DestinationIPPrefix != "") {
+		return MeteringLabelRule{}, fmt.Errorf("remote_ip_prefix cannot be combined with source or destination prefixes")
+	}
+	if err := c.requireExtension(meteringExtension); err != nil {
+		return MeteringLabelRule{}, err
+	}
+
+	var r SingleMeteringLabelRule
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/metering/metering-label-rules", c.URL), SingleMeteringLabelRule{MeteringLabelRule: rule}, http.StatusCreated, &r)
+	if err != nil {
+		return MeteringLabelRule{}, err
+	}
+	return r.MeteringLabelRule, nil
+}
+
+func (c *Client) DeleteMeteringLabelRule(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(meteringExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/metering/metering-label-rules/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
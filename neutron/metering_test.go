@@ -0,0 +1,128 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const meteringLabelsResp = `{
+  "metering_labels": [
+    {
+      "project_id": "45345b0ee1ea477fac0f541b2cb79cd4",
+      "tenant_id": "45345b0ee1ea477fac0f541b2cb79cd4",
+      "description": "label1 description",
+      "name": "label1",
+      "id": "a6700594-5b7a-4105-8bfe-723b346ce866",
+      "shared": false
+    }
+  ]
+}`
+
+const meteringLabelRuleResp = `{
+  "metering_label_rule": {
+    "remote_ip_prefix": null,
+    "source_ip_prefix": "10.0.0.0/24",
+    "destination_ip_prefix": null,
+    "direction": "ingress",
+    "metering_label_id": "e131d186-b02d-4c0b-83d5-0c0725c4f812",
+    "id": "00e13b58-b4f2-4579-9c9c-7ac94615f9ae",
+    "excluded": false
+  }
+}`
+
+var _ = Describe("Metering", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		method   string
+		path     string
+		query    url.Values
+		body     []byte
+		requests int
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests++
+			method = r.Method
+			path = r.URL.Path
+			query = r.URL.Query()
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, meteringLabelRuleResp)
+			default:
+				fmt.Fprintln(w, meteringLabelsResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists metering labels of a project", func() {
+		labels, err := client.MeteringLabels(neutron.ListOpts{ProjectID: "45345b0ee1ea477fac0f541b2cb79cd4"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/metering/metering-labels"))
+		Expect(query.Get("project_id")).To(Equal("45345b0ee1ea477fac0f541b2cb79cd4"))
+		Expect(labels).To(HaveLen(1))
+		Expect(labels[0].Name).To(Equal("label1"))
+	})
+
+	It("creates a metering label rule", func() {
+		rule, err := client.CreateMeteringLabelRule(neutron.MeteringLabelRule{
+			MeteringLabelID: "e131d186-b02d-4c0b-83d5-0c0725c4f812",
+			Direction:       neutron.MeteringDirectionIngress,
+			SourceIPPrefix:  "10.0.0.0/24",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(path).To(Equal("/v2.0/metering/metering-label-rules"))
+		Expect(body).To(MatchJSON(`{
+			"metering_label_rule": {
+				"metering_label_id": "e131d186-b02d-4c0b-83d5-0c0725c4f812",
+				"direction": "ingress",
+				"source_ip_prefix": "10.0.0.0/24"
+			}
+		}`))
+		Expect(rule.ID).To(Equal("00e13b58-b4f2-4579-9c9c-7ac94615f9ae"))
+		Expect(rule.Direction).To(Equal(neutron.MeteringDirectionIngress))
+	})
+
+	It("rejects rules mixing remote and source prefixes", func() {
+		_, err := client.CreateMeteringLabelRule(neutron.MeteringLabelRule{
+			MeteringLabelID: "label1",
+			RemoteIPPrefix:  "10.0.0.0/24",
+			SourceIPPrefix:  "10.0.1.0/24",
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(requests).To(Equal(0))
+	})
+
+	It("deletes a metering label", func() {
+		err := client.DeleteMeteringLabel("label1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/metering/metering-labels/label1"))
+	})
+})
@@ -0,0 +1,116 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// NDPProxy makes the router answer neighbor solicitations for an IPv6 address
+// of a port, publishing the address to the external network.
+type NDPProxy struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	RouterID    string `json:"router_id,omitempty"`
+	PortID      string `json:"port_id,omitempty"`
+	IPAddress   string `json:"ip_address,omitempty"`
+	TenantID    string `json:"tenant_id,omitempty"`
+	ProjectID   string `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetNDPProxies struct {
+	NDPProxies []NDPProxy `json:"ndp_proxies"`
+}
+
+type SingleNDPProxy struct {
+	NDPProxy NDPProxy `json:"ndp_proxy"`
+}
+
+// NDPProxyUpdate holds the NDP proxy attributes to change; nil fields are
+// left as is.
+type NDPProxyUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+const ndpProxyExtension = "l3-ndp-proxy"
+
+func (c *Client) NDPProxies(opts ...ListOpts) ([]NDPProxy, error) {
+	if err := c.requireExtension(ndpProxyExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetNDPProxies
+	err := c.send(http.MethodGet, c.listURL("ndp_proxies", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.NDPProxies, nil
+}
+
+func (c *Client) NDPProxy(id string) (NDPProxy, error) {
+	if id == "" {
+		return NDPProxy{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(ndpProxyExtension); err != nil {
+		return NDPProxy{}, err
+	}
+
+	var r SingleNDPProxy
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/ndp_proxies/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return NDPProxy{}, err
+	}
+	return r.NDPProxy, nil
+}
+
+func (c *Client) CreateNDPProxy(p NDPProxy) (NDPProxy, error) {
+	if p.RouterID == "" {
+		return NDPProxy{}, fmt.Errorf("missing router id")
+	}
+	if p.PortID == "" {
+		return NDPProxy{}, fmt.Errorf("missing port id")
+	}
+	if err := c.requireExtension(ndpProxyExtension); err != nil {
+		return NDPProxy{}, err
+	}
+
+	var r SingleNDPProxy
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/ndp_proxies", c.URL), SingleNDPProxy{NDPProxy: p}, http.StatusCreated, &r)
+	if err != nil {
+		return NDPProxy{}, err
+	}
+	return r.NDPProxy, nil
+}
+
+func (c *Client) UpdateNDPProxy(id string, u NDPProxyUpdate) (NDPProxy, error) {
+	if id == "" {
+		return NDPProxy{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(ndpProxyExtension); err != nil {
+		return NDPProxy{}, err
+	}
+
+	var r SingleNDPProxy
+	body := map[string]NDPProxyUpdate{"ndp_proxy": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/ndp_proxies/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return NDPProxy{}, err
+	}
+	return r.NDPProxy, nil
+}
+
+func (c *Client) DeleteNDPProxy(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(ndpProxyExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/ndp_proxies/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
@@ -0,0 +1,147 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const ndpProxiesResp = `{
+  "ndp_proxies": [
+    {
+      "id": "6b2c8a8e-5a3e-4d1c-9f4e-7b2d1c0e9a88",
+      "name": "web",
+      "router_id": "router1",
+      "port_id": "port1",
+      "ip_address": "2001:db8::10",
+      "project_id": "45977fa2dbd7482098dd68d0d8970117"
+    }
+  ]
+}`
+
+const ndpProxyResp = `{
+  "ndp_proxy": {
+    "id": "6b2c8a8e-5a3e-4d1c-9f4e-7b2d1c0e9a88",
+    "name": "web",
+    "description": "public web server",
+    "router_id": "router1",
+    "port_id": "port1",
+    "ip_address": "2001:db8::10"
+  }
+}`
+
+var _ = Describe("NDP proxies", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		method, path, body = "", "", nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			method = r.Method
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body)
+			case r.Method == http.MethodPut:
+				w.Write(body)
+			case r.URL.Path == "/v2.0/ndp_proxies":
+				fmt.Fprintln(w, ndpProxiesResp)
+			default:
+				fmt.Fprintln(w, ndpProxyResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("creates an NDP proxy", func() {
+		p, err := client.CreateNDPProxy(neutron.NDPProxy{
+			Name:      "web",
+			RouterID:  "router1",
+			PortID:    "port1",
+			IPAddress: "2001:db8::10",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/ndp_proxies"))
+		Expect(body).To(MatchJSON(`{
+			"ndp_proxy": {"name": "web", "router_id": "router1", "port_id": "port1", "ip_address": "2001:db8::10"}
+		}`))
+		Expect(p.IPAddress).To(Equal("2001:db8::10"))
+	})
+
+	It("requires a router and a port", func() {
+		_, err := client.CreateNDPProxy(neutron.NDPProxy{PortID: "port1"})
+		Expect(err).To(MatchError("missing router id"))
+	})
+
+	It("lists NDP proxies", func() {
+		proxies, err := client.NDPProxies()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodGet))
+		Expect(path).To(Equal("/v2.0/ndp_proxies"))
+		Expect(proxies).To(Equal([]neutron.NDPProxy{{
+			ID:        "6b2c8a8e-5a3e-4d1c-9f4e-7b2d1c0e9a88",
+			Name:      "web",
+			RouterID:  "router1",
+			PortID:    "port1",
+			IPAddress: "2001:db8::10",
+			ProjectID: "45977fa2dbd7482098dd68d0d8970117",
+		}}))
+	})
+
+	It("gets an NDP proxy", func() {
+		p, err := client.NDPProxy("6b2c8a8e-5a3e-4d1c-9f4e-7b2d1c0e9a88")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/ndp_proxies/6b2c8a8e-5a3e-4d1c-9f4e-7b2d1c0e9a88"))
+		Expect(p.Description).To(Equal("public web server"))
+	})
+
+	It("updates an NDP proxy", func() {
+		name := "www"
+		p, err := client.UpdateNDPProxy("6b2c8a8e-5a3e-4d1c-9f4e-7b2d1c0e9a88", neutron.NDPProxyUpdate{Name: &name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/ndp_proxies/6b2c8a8e-5a3e-4d1c-9f4e-7b2d1c0e9a88"))
+		Expect(body).To(MatchJSON(`{"ndp_proxy": {"name": "www"}}`))
+		Expect(p.Name).To(Equal("www"))
+	})
+
+	It("deletes an NDP proxy", func() {
+		err := client.DeleteNDPProxy("6b2c8a8e-5a3e-4d1c-9f4e-7b2d1c0e9a88")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/ndp_proxies/6b2c8a8e-5a3e-4d1c-9f4e-7b2d1c0e9a88"))
+	})
+
+	It("requires an id", func() {
+		_, err := client.NDPProxy("")
+		Expect(err).To(MatchError("empty 'id' parameter"))
+		err = client.DeleteNDPProxy("")
+		Expect(err).To(MatchError("empty 'id' parameter"))
+		Expect(method).To(BeEmpty())
+	})
+})
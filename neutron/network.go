@@ -1,5 +1,7 @@
 package neutron
 
+import "time"
+
 type Network struct {
 	ID           string   `json:"id,omitempty"`
 	Name         string   `json:"name"`
@@ -10,6 +12,33 @@ type Network struct {
 	TenantID     string   `json:"tenant_id,omitempty"`
 	MTU          int      `json:"mtu,omitempty"`
 	ProjectID    string   `json:"project_id,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	DNSDomain    string   `json:"dns_domain,omitempty"`
+
+	// AvailabilityZoneHints are the zones requested for the DHCP agents of
+	// the network; AvailabilityZones are the zones Neutron scheduled them to.
+	AvailabilityZoneHints []string `json:"availability_zone_hints,omitempty"`
+	AvailabilityZones     []string `json:"availability_zones,omitempty"`
+
+	// RevisionNumber, CreatedAt and UpdatedAt are read only; they are never
+	// sent to Neutron.
+	RevisionNumber int       `json:"-"`
+	CreatedAt      time.Time `json:"-"`
+	UpdatedAt      time.Time `json:"-"`
+
+	Extras Extras `json:"-"`
+}
+
+// NetworkUpdate holds the network attributes to change; nil fields are left
+// as is.
+type NetworkUpdate struct {
+	Name         *string `json:"name,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	AdminStateUp *bool   `json:"admin_state_up,omitempty"`
+	MTU          *int    `json:"mtu,omitempty"`
+	DNSDomain    *string `json:"dns_domain,omitempty"`
+
+	Extras Extras `json:"-"`
 }
 
 type GetNetworks struct {
@@ -1,5 +1,7 @@
 package neutron
 
+import "time"
+
 type Port struct {
 	ID           string    `json:"id,omitempty"`
 	Name         string    `json:"name,omitempty"`
@@ -11,6 +13,53 @@ type Port struct {
 	DeviceOwner  string    `json:"device_owner,omitempty"`
 	DeviceID     string    `json:"device_id,omitempty"`
 	FixedIPs     []FixedIP `json:"fixed_ips,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+
+	BindingHostID     string                 `json:"binding:host_id,omitempty"`
+	BindingVIFType    string                 `json:"binding:vif_type,omitempty"`
+	BindingVIFDetails map[string]interface{} `json:"binding:vif_details,omitempty"`
+	BindingVNICType   string                 `json:"binding:vnic_type,omitempty"`
+	BindingProfile    map[string]interface{} `json:"binding:profile,omitempty"`
+
+	AllowedAddressPairs []AddressPair  `json:"allowed_address_pairs,omitempty"`
+	ExtraDHCPOpts       []ExtraDHCPOpt `json:"extra_dhcp_opts,omitempty"`
+	PortSecurityEnabled *bool          `json:"port_security_enabled,omitempty"`
+
+	DNSName       string          `json:"dns_name,omitempty"`
+	DNSDomain     string          `json:"dns_domain,omitempty"`
+	DNSAssignment []DNSAssignment `json:"dns_assignment,omitempty"`
+
+	// RevisionNumber, CreatedAt and UpdatedAt are read only; they are never
+	// sent to Neutron.
+	RevisionNumber int       `json:"-"`
+	CreatedAt      time.Time `json:"-"`
+	UpdatedAt      time.Time `json:"-"`
+
+	Extras Extras `json:"-"`
+}
+
+// PortUpdate holds the port attributes to change; nil fields are left as is.
+type PortUpdate struct {
+	Name         *string    `json:"name,omitempty"`
+	AdminStateUp *bool      `json:"admin_state_up,omitempty"`
+	DeviceOwner  *string    `json:"device_owner,omitempty"`
+	DeviceID     *string    `json:"device_id,omitempty"`
+	FixedIPs     *[]FixedIP `json:"fixed_ips,omitempty"`
+
+	BindingHostID   *string                `json:"binding:host_id,omitempty"`
+	BindingVNICType *string                `json:"binding:vnic_type,omitempty"`
+	BindingProfile  map[string]interface{} `json:"binding:profile,omitempty"`
+
+	// AllowedAddressPairs replaces the whole list, while ExtraDHCPOpts are
+	// merged by option name into the existing options.
+	AllowedAddressPairs *[]AddressPair  `json:"allowed_address_pairs,omitempty"`
+	ExtraDHCPOpts       *[]ExtraDHCPOpt `json:"extra_dhcp_opts,omitempty"`
+	PortSecurityEnabled *bool           `json:"port_security_enabled,omitempty"`
+
+	DNSName   *string `json:"dns_name,omitempty"`
+	DNSDomain *string `json:"dns_domain,omitempty"`
+
+	Extras Extras `json:"-"`
 }
 
 type FixedIP struct {
@@ -18,6 +67,17 @@ type FixedIP struct {
 	SubnetID  string `json:"subnet_id"`
 }
 
+type AddressPair struct {
+	IPAddress  string `json:"ip_address"`
+	MacAddress string `json:"mac_address,omitempty"`
+}
+
+type ExtraDHCPOpt struct {
+	OptName   string `json:"opt_name"`
+	OptValue  string `json:"opt_value"`
+	IPVersion int    `json:"ip_version,omitempty"`
+}
+
 type GetPorts struct {
 	Ports []Port `json:"ports"`
 }
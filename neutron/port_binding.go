@@ -0,0 +1,115 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// VNIC types accepted in binding:vnic_type.
+const (
+	VNICTypeNormal          = "normal"
+	VNICTypeDirect          = "direct"
+	VNICTypeDirectPhysical  = "direct-physical"
+	VNICTypeMacvtap         = "macvtap"
+	VNICTypeBaremetal       = "baremetal"
+	VNICTypeVirtioForwarder = "virtio-forwarder"
+)
+
+// PortBinding is one of the bindings of a port to a host, as exposed by the
+// extended port bindings API used during live migration.
+type PortBinding struct {
+	Host       string                 `json:"host,omitempty"`
+	VIFType    string                 `json:"vif_type,omitempty"`
+	VIFDetails map[string]interface{} `json:"vif_details,omitempty"`
+	VNICType   string                 `json:"vnic_type,omitempty"`
+	Profile    map[string]interface{} `json:"profile,omitempty"`
+	Status     string                 `json:"status,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetPortBindings struct {
+	Bindings []PortBinding `json:"bindings"`
+}
+
+type SinglePortBinding struct {
+	Binding PortBinding `json:"binding"`
+}
+
+func (c *Client) PortBindings(portID string) ([]PortBinding, error) {
+	if portID == "" {
+		return nil, fmt.Errorf("empty 'portID' parameter")
+	}
+	if err := c.requireExtension("binding-extended"); err != nil {
+		return nil, err
+	}
+
+	var r GetPortBindings
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/ports/%s/bindings", c.URL, portID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Bindings, nil
+}
+
+// CreatePortBinding adds an inactive binding of the port to b.Host, e.g. the
+// destination host of a live migration.
+func (c *Client) CreatePortBinding(portID string, b PortBinding) (PortBinding, error) {
+	if portID == "" {
+		return PortBinding{}, fmt.Errorf("empty 'portID' parameter")
+	}
+	if b.Host == "" {
+		return PortBinding{}, fmt.Errorf("missing binding host")
+	}
+	if err := c.requireExtension("binding-extended"); err != nil {
+		return PortBinding{}, err
+	}
+
+	in := SinglePortBinding{Binding: PortBinding{
+		Host:     b.Host,
+		VNICType: b.VNICType,
+		Profile:  b.Profile,
+	}}
+
+	var r SinglePortBinding
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/ports/%s/bindings", c.URL, portID), in, http.StatusCreated, &r)
+	if err != nil {
+		return PortBinding{}, err
+	}
+	return r.Binding, nil
+}
+
+// ActivatePortBinding makes the binding of the port to host the active one,
+// deactivating the previously active binding.
+func (c *Client) ActivatePortBinding(portID, host string) (PortBinding, error) {
+	if portID == "" {
+		return PortBinding{}, fmt.Errorf("empty 'portID' parameter")
+	}
+	if host == "" {
+		return PortBinding{}, fmt.Errorf("empty 'host' parameter")
+	}
+	if err := c.requireExtension("binding-extended"); err != nil {
+		return PortBinding{}, err
+	}
+
+	var r SinglePortBinding
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/ports/%s/bindings/%s/activate", c.URL, portID, host), nil, http.StatusOK, &r)
+	if err != nil {
+		return PortBinding{}, err
+	}
+	return r.Binding, nil
+}
+
+func (c *Client) DeletePortBinding(portID, host string) error {
+	if portID == "" {
+		return fmt.Errorf("empty 'portID' parameter")
+	}
+	if host == "" {
+		return fmt.Errorf("empty 'host' parameter")
+	}
+	if err := c.requireExtension("binding-extended"); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/ports/%s/bindings/%s", c.URL, portID, host), nil, http.StatusNoContent, nil)
+}
@@ -0,0 +1,236 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const boundPortResp = `{
+  "port": {
+    "admin_state_up": true,
+    "binding:host_id": "compute1",
+    "binding:profile": {},
+    "binding:vif_details": {
+      "ovs_hybrid_plug": true,
+      "port_filter": true
+    },
+    "binding:vif_type": "ovs",
+    "binding:vnic_type": "normal",
+    "device_id": "d6b4d3a5-c700-476f-b609-1493dd9dadc0",
+    "device_owner": "compute:nova",
+    "id": "ebe69f1e-bc26-4db5-bed0-c0afb4afe3db",
+    "name": "port1",
+    "network_id": "6aeaf34a-c482-4bd3-9dc3-7faf36412f12",
+    "status": "DOWN",
+    "tenant_id": "cf1a5775e766426cb1968766d0191908"
+  }
+}`
+
+const portsResp = `{
+  "ports": [
+    {
+      "admin_state_up": true,
+      "binding:host_id": "compute1",
+      "binding:vif_type": "binding_failed",
+      "binding:vnic_type": "normal",
+      "id": "ebe69f1e-bc26-4db5-bed0-c0afb4afe3db",
+      "name": "port1",
+      "network_id": "6aeaf34a-c482-4bd3-9dc3-7faf36412f12",
+      "status": "DOWN"
+    }
+  ]
+}`
+
+const portBindingsResp = `{
+  "bindings": [
+    {
+      "host": "compute1",
+      "vif_type": "ovs",
+      "vif_details": {"port_filter": true},
+      "vnic_type": "normal",
+      "profile": {},
+      "status": "ACTIVE"
+    },
+    {
+      "host": "compute2",
+      "vif_type": "ovs",
+      "vnic_type": "normal",
+      "profile": {},
+      "status": "INACTIVE"
+    }
+  ]
+}`
+
+const portBindingResp = `{
+  "binding": {
+    "host": "compute2",
+    "vif_type": "ovs",
+    "vif_details": {"port_filter": true},
+    "vnic_type": "normal",
+    "profile": {},
+    "status": "ACTIVE"
+  }
+}`
+
+var _ = Describe("Port bindings", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		query  url.Values
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			method = r.Method
+			path = r.URL.Path
+			query = r.URL.Query()
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.URL.Path == "/v2.0/ports" && r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, boundPortResp)
+			case r.URL.Path == "/v2.0/ports":
+				fmt.Fprintln(w, portsResp)
+			case r.URL.Path == "/v2.0/ports/port1/bindings" && r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, portBindingResp)
+			case r.URL.Path == "/v2.0/ports/port1/bindings":
+				fmt.Fprintln(w, portBindingsResp)
+			case r.URL.Path == "/v2.0/ports/port1/bindings/compute2/activate":
+				fmt.Fprintln(w, portBindingResp)
+			default:
+				fmt.Fprintln(w, boundPortResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Port", func() {
+		It("reads binding attributes", func() {
+			p, err := client.Port("port1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/ports/port1"))
+			Expect(p.BindingHostID).To(Equal("compute1"))
+			Expect(p.BindingVIFType).To(Equal("ovs"))
+			Expect(p.BindingVNICType).To(Equal(neutron.VNICTypeNormal))
+			Expect(p.BindingVIFDetails).To(HaveKeyWithValue("port_filter", true))
+			Expect(p.BindingProfile).To(BeEmpty())
+		})
+
+		Context("when port id is invalid", func() {
+			It("returns an error", func() {
+				_, err := client.Port("")
+				Expect(err).To(MatchError("empty 'id' parameter"))
+			})
+		})
+	})
+
+	Describe("Ports", func() {
+		It("lists ports", func() {
+			ports, err := client.Ports(neutron.ListOpts{
+				Filters: map[string]string{"binding:host_id": "compute1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(query.Get("binding:host_id")).To(Equal("compute1"))
+			Expect(ports).To(HaveLen(1))
+			Expect(ports[0].BindingVIFType).To(Equal("binding_failed"))
+		})
+	})
+
+	Describe("CreatePort", func() {
+		It("sends binding attributes", func() {
+			_, err := client.CreatePort(neutron.Port{
+				NetworkID:       "network1",
+				BindingHostID:   "compute1",
+				BindingVNICType: neutron.VNICTypeDirect,
+				BindingProfile:  map[string]interface{}{"pci_slot": "0000:03:10.1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"port": {
+					"network_id": "network1",
+					"binding:host_id": "compute1",
+					"binding:vnic_type": "direct",
+					"binding:profile": {"pci_slot": "0000:03:10.1"}
+				}
+			}`))
+		})
+	})
+
+	Describe("UpdatePort", func() {
+		It("sends only the given attributes", func() {
+			host := "compute2"
+			p, err := client.UpdatePort("port1", neutron.PortUpdate{BindingHostID: &host})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/ports/port1"))
+			Expect(body).To(MatchJSON(`{"port": {"binding:host_id": "compute2"}}`))
+			Expect(p.ID).To(Equal("ebe69f1e-bc26-4db5-bed0-c0afb4afe3db"))
+		})
+	})
+
+	Describe("extended port bindings", func() {
+		It("lists the bindings of a port", func() {
+			bindings, err := client.PortBindings("port1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bindings).To(HaveLen(2))
+			Expect(bindings[0].Status).To(Equal("ACTIVE"))
+			Expect(bindings[1].Host).To(Equal("compute2"))
+		})
+
+		It("creates a binding on another host", func() {
+			b, err := client.CreatePortBinding("port1", neutron.PortBinding{
+				Host:     "compute2",
+				VNICType: neutron.VNICTypeNormal,
+				Status:   "ignored",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(body).To(MatchJSON(`{"binding": {"host": "compute2", "vnic_type": "normal"}}`))
+			Expect(b.Host).To(Equal("compute2"))
+		})
+
+		It("requires a host to create a binding", func() {
+			_, err := client.CreatePortBinding("port1", neutron.PortBinding{})
+			Expect(err).To(MatchError("missing binding host"))
+		})
+
+		It("activates a binding", func() {
+			b, err := client.ActivatePortBinding("port1", "compute2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/ports/port1/bindings/compute2/activate"))
+			Expect(b.Status).To(Equal("ACTIVE"))
+		})
+
+		It("deletes a binding", func() {
+			err := client.DeletePortBinding("port1", "compute1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/ports/port1/bindings/compute1"))
+		})
+	})
+})
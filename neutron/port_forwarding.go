@@ -0,0 +1,251 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ProtocolTCP = "tcp"
+	ProtocolUDP = "udp"
+)
+
+// PortForwarding maps an external port, or port range such as "8080:8090",
+// of a floating IP to an internal port of a Neutron port.
+type PortForwarding struct {
+	ID                string `json:"id,omitempty"`
+	InternalPortID    string `json:"internal_port_id,omitempty"`
+	InternalIPAddress string `json:"internal_ip_address,omitempty"`
+	InternalPort      int    `json:"internal_port,omitempty"`
+	ExternalPort      int    `json:"external_port,omitempty"`
+	InternalPortRange string `json:"internal_port_range,omitempty"`
+	ExternalPortRange string `json:"external_port_range,omitempty"`
+	Protocol          string `json:"protocol,omitempty"`
+	Description       string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// PortForwardingUpdate holds the port forwarding attributes to change; nil
+// fields are left as is.
+type PortForwardingUpdate struct {
+	InternalPortID    *string `json:"internal_port_id,omitempty"`
+	InternalIPAddress *string `json:"internal_ip_address,omitempty"`
+	InternalPort      *int    `json:"internal_port,omitempty"`
+	ExternalPort      *int    `json:"external_port,omitempty"`
+	InternalPortRange *string `json:"internal_port_range,omitempty"`
+	ExternalPortRange *string `json:"external_port_range,omitempty"`
+	Protocol          *string `json:"protocol,omitempty"`
+	Description       *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetPortForwardings struct {
+	PortForwardings []PortForwarding `json:"port_forwardings"`
+}
+
+type SinglePortForwarding struct {
+	PortForwarding PortForwarding `json:"port_forwarding"`
+}
+
+// externalPorts returns the first and last external port of the rule.
+func (pf PortForwarding) externalPorts() (int, int, error) {
+	if pf.ExternalPortRange == "" {
+		if pf.ExternalPort <= 0 || pf.ExternalPort > 65535 {
+			return 0, 0, fmt.Errorf("invalid external port %d", pf.ExternalPort)
+		}
+		return pf.ExternalPort, pf.ExternalPort, nil
+	}
+
+	parts := strings.SplitN(pf.ExternalPortRange, ":", 2)
+	if len(parts) == 1 {
+		parts = append(parts, parts[0])
+	}
+	first, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid external port range '%s'", pf.ExternalPortRange)
+	}
+	last, err := strconv.Atoi(parts[1])
+	if err != nil || first <= 0 || last > 65535 || first > last {
+		return 0, 0, fmt.Errorf("invalid external port range '%s'", pf.ExternalPortRange)
+	}
+	return first, last, nil
+}
+
+// ValidatePortForwardings checks that no two rules use overlapping external
+// ports for the same protocol, as Neutron would reject the later one.
+func ValidatePortForwardings(rules []PortForwarding) error {
+	type span struct {
+		first, last int
+	}
+	used := map[string][]span{}
+
+	for _, r := range rules {
+		if r.Protocol == "" {
+			return fmt.Errorf("missing protocol")
+		}
+		first, last, err := r.externalPorts()
+		if err != nil {
+			return err
+		}
+		protocol := strings.ToLower(r.Protocol)
+		for _, s := range used[protocol] {
+			if first <= s.last && s.first <= last {
+				return fmt.Errorf("external port %s conflicts with %s for protocol %s",
+					portSpan(first, last), portSpan(s.first, s.last), protocol)
+			}
+		}
+		used[protocol] = append(used[protocol], span{first: first, last: last})
+	}
+	return nil
+}
+
+func portSpan(first, last int) string {
+	if first == last {
+		return strconv.Itoa(first)
+	}
+	return fmt.Sprintf("%d:%d", first, last)
+}
+
+func (c *Client) PortForwardings(floatingIPID string) ([]PortForwarding, error) {
+	if floatingIPID == "" {
+		return nil, fmt.Errorf("empty 'floatingIPID' parameter")
+	}
+	if err := c.requireExtension("floating-ip-port-forwarding"); err != nil {
+		return nil, err
+	}
+
+	var r GetPortForwardings
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/floatingips/%s/port_forwardings", c.URL, floatingIPID), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.PortForwardings, nil
+}
+
+func (c *Client) PortForwarding(floatingIPID, id string) (PortForwarding, error) {
+	if floatingIPID == "" {
+		return PortForwarding{}, fmt.Errorf("empty 'floatingIPID' parameter")
+	}
+	if id == "" {
+		return PortForwarding{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension("floating-ip-port-forwarding"); err != nil {
+		return PortForwarding{}, err
+	}
+
+	var r SinglePortForwarding
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/floatingips/%s/port_forwardings/%s", c.URL, floatingIPID, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return PortForwarding{}, err
+	}
+	return r.PortForwarding, nil
+}
+
+// CreatePortForwarding validates the rule against the existing rules of the
+// floating IP before creating it.
+func (c *Client) CreatePortForwarding(floatingIPID string, pf PortForwarding) (PortForwarding, error) {
+	if floatingIPID == "" {
+		return PortForwarding{}, fmt.Errorf("empty 'floatingIPID' parameter")
+	}
+
+	existing, err := c.PortForwardings(floatingIPID)
+	if err != nil {
+		return PortForwarding{}, err
+	}
+	if err := ValidatePortForwardings(append(existing, pf)); err != nil {
+		return PortForwarding{}, err
+	}
+
+	var r SinglePortForwarding
+	err = c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/floatingips/%s/port_forwardings", c.URL, floatingIPID), SinglePortForwarding{PortForwarding: pf}, http.StatusCreated, &r)
+	if err != nil {
+		return PortForwarding{}, err
+	}
+	return r.PortForwarding, nil
+}
+
+// UpdatePortForwarding changes the attributes set in u of the rule with id.
+// When the external ports or the protocol change, the updated rule is
+// validated against the other rules of the floating IP first.
+func (c *Client) UpdatePortForwarding(floatingIPID, id string, u PortForwardingUpdate) (PortForwarding, error) {
+	if floatingIPID == "" {
+		return PortForwarding{}, fmt.Errorf("empty 'floatingIPID' parameter")
+	}
+	if id == "" {
+		return PortForwarding{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension("floating-ip-port-forwarding"); err != nil {
+		return PortForwarding{}, err
+	}
+
+	if u.ExternalPort != nil || u.ExternalPortRange != nil || u.Protocol != nil {
+		if err := c.validatePortForwardingUpdate(floatingIPID, id, u); err != nil {
+			return PortForwarding{}, err
+		}
+	}
+
+	var r SinglePortForwarding
+	body := map[string]PortForwardingUpdate{"port_forwarding": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/floatingips/%s/port_forwardings/%s", c.URL, floatingIPID, id), body, http.StatusOK, &r)
+	if err != nil {
+		return PortForwarding{}, err
+	}
+	return r.PortForwarding, nil
+}
+
+// validatePortForwardingUpdate applies the external ports and protocol of u
+// to the existing rule with id and validates it against the other rules.
+func (c *Client) validatePortForwardingUpdate(floatingIPID, id string, u PortForwardingUpdate) error {
+	existing, err := c.PortForwardings(floatingIPID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		rules   []PortForwarding
+		updated PortForwarding
+		found   bool
+	)
+	for _, r := range existing {
+		if r.ID != id {
+			rules = append(rules, r)
+			continue
+		}
+		updated, found = r, true
+	}
+	if !found {
+		// let Neutron report the missing rule
+		return nil
+	}
+	if u.ExternalPort != nil || u.ExternalPortRange != nil {
+		updated.ExternalPort, updated.ExternalPortRange = 0, ""
+		if u.ExternalPort != nil {
+			updated.ExternalPort = *u.ExternalPort
+		}
+		if u.ExternalPortRange != nil {
+			updated.ExternalPortRange = *u.ExternalPortRange
+		}
+	}
+	if u.Protocol != nil {
+		updated.Protocol = *u.Protocol
+	}
+	return ValidatePortForwardings(append(rules, updated))
+}
+
+func (c *Client) DeletePortForwarding(floatingIPID, id string) error {
+	if floatingIPID == "" {
+		return fmt.Errorf("empty 'floatingIPID' parameter")
+	}
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension("floating-ip-port-forwarding"); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/floatingips/%s/port_forwardings/%s", c.URL, floatingIPID, id), nil, http.StatusNoContent, nil)
+}
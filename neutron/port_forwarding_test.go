@@ -0,0 +1,234 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const portForwardingsResp = `{
+  "port_forwardings": [
+    {
+      "protocol": "tcp",
+      "internal_ip_address": "10.0.0.11",
+      "internal_port": 25,
+      "internal_port_id": "1238be08-a2a8-4b8d-addf-fb5e2250e480",
+      "external_port": 2230,
+      "description": "smtp",
+      "id": "da554833-9f8d-4a3c-b9bc-d44eb4ef5a37"
+    },
+    {
+      "protocol": "tcp",
+      "internal_ip_address": "10.0.0.12",
+      "internal_port_range": "8000:8010",
+      "internal_port_id": "e0a0274e-4d19-4eab-9e12-9e77a8caf3ea",
+      "external_port_range": "9000:9010",
+      "description": "web",
+      "id": "aaaaaaaa-9f8d-4a3c-b9bc-d44eb4ef5a37"
+    }
+  ]
+}`
+
+const portForwardingResp = `{
+  "port_forwarding": {
+    "protocol": "udp",
+    "internal_ip_address": "10.0.0.11",
+    "internal_port": 53,
+    "internal_port_id": "1238be08-a2a8-4b8d-addf-fb5e2250e480",
+    "external_port": 2230,
+    "description": "dns",
+    "id": "725ade3c-9760-4880-8080-8fc2dbab9acc"
+  }
+}`
+
+var _ = Describe("Port forwardings", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		method   string
+		path     string
+		body     []byte
+		requests int
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests++
+			method = r.Method
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, portForwardingResp)
+			case r.URL.Path == "/v2.0/floatingips/fip1/port_forwardings":
+				fmt.Fprintln(w, portForwardingsResp)
+			default:
+				fmt.Fprintln(w, portForwardingResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists port forwardings of a floating IP", func() {
+		pfs, err := client.PortForwardings("fip1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pfs).To(HaveLen(2))
+		Expect(pfs[0].InternalPort).To(Equal(25))
+		Expect(pfs[0].ExternalPort).To(Equal(2230))
+		Expect(pfs[1].ExternalPortRange).To(Equal("9000:9010"))
+	})
+
+	It("shows a port forwarding", func() {
+		pf, err := client.PortForwarding("fip1", "725ade3c-9760-4880-8080-8fc2dbab9acc")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/floatingips/fip1/port_forwardings/725ade3c-9760-4880-8080-8fc2dbab9acc"))
+		Expect(pf.Protocol).To(Equal(neutron.ProtocolUDP))
+	})
+
+	Describe("CreatePortForwarding", func() {
+		It("creates a port forwarding", func() {
+			pf, err := client.CreatePortForwarding("fip1", neutron.PortForwarding{
+				InternalPortID:    "1238be08-a2a8-4b8d-addf-fb5e2250e480",
+				InternalIPAddress: "10.0.0.11",
+				InternalPort:      53,
+				ExternalPort:      2230,
+				Protocol:          neutron.ProtocolUDP,
+				Description:       "dns",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPost))
+			Expect(path).To(Equal("/v2.0/floatingips/fip1/port_forwardings"))
+			Expect(body).To(MatchJSON(`{
+				"port_forwarding": {
+					"internal_port_id": "1238be08-a2a8-4b8d-addf-fb5e2250e480",
+					"internal_ip_address": "10.0.0.11",
+					"internal_port": 53,
+					"external_port": 2230,
+					"protocol": "udp",
+					"description": "dns"
+				}
+			}`))
+			Expect(pf.ID).To(Equal("725ade3c-9760-4880-8080-8fc2dbab9acc"))
+		})
+
+		Context("when the external port conflicts with an existing rule", func() {
+			It("returns an error without submitting", func() {
+				_, err := client.CreatePortForwarding("fip1", neutron.PortForwarding{
+					InternalPortID:    "1238be08-a2a8-4b8d-addf-fb5e2250e480",
+					InternalIPAddress: "10.0.0.11",
+					InternalPort:      80,
+					ExternalPort:      9005,
+					Protocol:          neutron.ProtocolTCP,
+				})
+				Expect(err).To(MatchError("external port 9005 conflicts with 9000:9010 for protocol tcp"))
+				Expect(requests).To(Equal(1))
+			})
+		})
+
+		It("requires a floating IP", func() {
+			_, err := client.CreatePortForwarding("", neutron.PortForwarding{ExternalPort: 2230, Protocol: neutron.ProtocolUDP})
+			Expect(err).To(MatchError("empty 'floatingIPID' parameter"))
+			Expect(requests).To(BeZero())
+		})
+	})
+
+	It("updates a port forwarding", func() {
+		description := "new"
+		_, err := client.UpdatePortForwarding("fip1", "pf1", neutron.PortForwardingUpdate{Description: &description})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/floatingips/fip1/port_forwardings/pf1"))
+		Expect(body).To(MatchJSON(`{"port_forwarding": {"description": "new"}}`))
+		Expect(requests).To(Equal(1))
+	})
+
+	It("validates updated external ports against the other rules", func() {
+		port := 9005
+		_, err := client.UpdatePortForwarding("fip1", "da554833-9f8d-4a3c-b9bc-d44eb4ef5a37", neutron.PortForwardingUpdate{
+			ExternalPort: &port,
+		})
+		Expect(err).To(MatchError("external port 9005 conflicts with 9000:9010 for protocol tcp"))
+		Expect(method).To(Equal(http.MethodGet))
+	})
+
+	It("validates an updated protocol against the other rules", func() {
+		portRange, protocol := "2200:2240", "udp"
+		_, err := client.UpdatePortForwarding("fip1", "aaaaaaaa-9f8d-4a3c-b9bc-d44eb4ef5a37", neutron.PortForwardingUpdate{
+			ExternalPortRange: &portRange,
+			Protocol:          &protocol,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+
+		_, err = client.UpdatePortForwarding("fip1", "aaaaaaaa-9f8d-4a3c-b9bc-d44eb4ef5a37", neutron.PortForwardingUpdate{
+			ExternalPortRange: &portRange,
+		})
+		Expect(err).To(MatchError("external port 2200:2240 conflicts with 2230 for protocol tcp"))
+	})
+
+	It("does not validate a rule against itself", func() {
+		portRange := "9005:9020"
+		_, err := client.UpdatePortForwarding("fip1", "aaaaaaaa-9f8d-4a3c-b9bc-d44eb4ef5a37", neutron.PortForwardingUpdate{
+			ExternalPortRange: &portRange,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal(2))
+	})
+
+	It("deletes a port forwarding", func() {
+		err := client.DeletePortForwarding("fip1", "pf1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/floatingips/fip1/port_forwardings/pf1"))
+	})
+
+	Describe("ValidatePortForwardings", func() {
+		It("allows the same external port for different protocols", func() {
+			err := neutron.ValidatePortForwardings([]neutron.PortForwarding{
+				{ExternalPort: 53, Protocol: neutron.ProtocolTCP},
+				{ExternalPort: 53, Protocol: neutron.ProtocolUDP},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("rejects overlapping port ranges", func() {
+			err := neutron.ValidatePortForwardings([]neutron.PortForwarding{
+				{ExternalPortRange: "100:200", Protocol: neutron.ProtocolTCP},
+				{ExternalPortRange: "200:300", Protocol: neutron.ProtocolTCP},
+			})
+			Expect(err).To(MatchError("external port 200:300 conflicts with 100:200 for protocol tcp"))
+		})
+
+		It("rejects invalid port ranges", func() {
+			err := neutron.ValidatePortForwardings([]neutron.PortForwarding{
+				{ExternalPortRange: "300:200", Protocol: neutron.ProtocolTCP},
+			})
+			Expect(err).To(MatchError("invalid external port range '300:200'"))
+		})
+
+		It("requires a protocol", func() {
+			err := neutron.ValidatePortForwardings([]neutron.PortForwarding{{ExternalPort: 22}})
+			Expect(err).To(MatchError("missing protocol"))
+		})
+	})
+})
@@ -0,0 +1,136 @@
+package neutron
+
+import (
+	"fmt"
+)
+
+// AddAllowedAddressPair adds pair to the allowed address pairs of the port,
+// keeping the pairs already set. When Neutron supports revision-if-match the
+// port is updated conditionally on the revision read, so pairs changed
+// concurrently by another client are not lost.
+func (c *Client) AddAllowedAddressPair(portID string, pair AddressPair) (Port, error) {
+	if portID == "" {
+		return Port{}, fmt.Errorf("empty 'portID' parameter")
+	}
+	if pair.IPAddress == "" {
+		return Port{}, fmt.Errorf("missing address pair IP address")
+	}
+	if err := c.requireExtension("allowed-address-pairs"); err != nil {
+		return Port{}, err
+	}
+
+	return c.modifyAddressPairs(portID, func(p Port) (*PortUpdate, error) {
+		for _, existing := range p.AllowedAddressPairs {
+			if sameAddressPair(existing, pair, p.MacAddress) {
+				return nil, nil
+			}
+		}
+		pairs := append(p.AllowedAddressPairs, pair)
+		return &PortUpdate{AllowedAddressPairs: &pairs}, nil
+	})
+}
+
+// RemoveAllowedAddressPair removes pair from the allowed address pairs of the
+// port, keeping the other pairs. Like AddAllowedAddressPair, it does not
+// overwrite concurrent changes when Neutron supports revision-if-match.
+func (c *Client) RemoveAllowedAddressPair(portID string, pair AddressPair) (Port, error) {
+	if portID == "" {
+		return Port{}, fmt.Errorf("empty 'portID' parameter")
+	}
+	if pair.IPAddress == "" {
+		return Port{}, fmt.Errorf("missing address pair IP address")
+	}
+	if err := c.requireExtension("allowed-address-pairs"); err != nil {
+		return Port{}, err
+	}
+
+	return c.modifyAddressPairs(portID, func(p Port) (*PortUpdate, error) {
+		pairs := []AddressPair{}
+		for _, existing := range p.AllowedAddressPairs {
+			if !sameAddressPair(existing, pair, p.MacAddress) {
+				pairs = append(pairs, existing)
+			}
+		}
+		if len(pairs) == len(p.AllowedAddressPairs) {
+			return nil, nil
+		}
+		return &PortUpdate{AllowedAddressPairs: &pairs}, nil
+	})
+}
+
+// modifyAddressPairs is modifyPort, falling back to a plain read and update
+// when Neutron does not support revision-if-match.
+func (c *Client) modifyAddressPairs(portID string, modify func(Port) (*PortUpdate, error)) (Port, error) {
+	ok, err := c.HasExtension(revisionIfMatchExtension)
+	if err != nil {
+		return Port{}, err
+	}
+	if ok {
+		return c.modifyPort(portID, modify)
+	}
+
+	p, err := c.Port(portID)
+	if err != nil {
+		return Port{}, err
+	}
+	u, err := modify(p)
+	if err != nil || u == nil {
+		return p, err
+	}
+	return c.UpdatePort(portID, *u)
+}
+
+// sameAddressPair compares pairs, treating an empty MAC address as the MAC
+// address of the port, which is what Neutron stores in that case.
+func sameAddressPair(a, b AddressPair, portMac string) bool {
+	macA, macB := a.MacAddress, b.MacAddress
+	if macA == "" {
+		macA = portMac
+	}
+	if macB == "" {
+		macB = portMac
+	}
+	return a.IPAddress == b.IPAddress && macA == macB
+}
+
+// SetExtraDHCPOpt adds or replaces a single DHCP option of the port, keeping
+// the other options.
+func (c *Client) SetExtraDHCPOpt(portID string, opt ExtraDHCPOpt) (Port, error) {
+	if portID == "" {
+		return Port{}, fmt.Errorf("empty 'portID' parameter")
+	}
+	if opt.OptName == "" {
+		return Port{}, fmt.Errorf("missing DHCP option name")
+	}
+	if err := c.requireExtension("extra_dhcp_opt"); err != nil {
+		return Port{}, err
+	}
+
+	opts := []ExtraDHCPOpt{opt}
+	return c.UpdatePort(portID, PortUpdate{ExtraDHCPOpts: &opts})
+}
+
+// RemoveExtraDHCPOpt removes a single DHCP option of the port, keeping the
+// other options. An ipVersion of 0 matches an option without an IP version.
+func (c *Client) RemoveExtraDHCPOpt(portID, name string, ipVersion int) (Port, error) {
+	if portID == "" {
+		return Port{}, fmt.Errorf("empty 'portID' parameter")
+	}
+	if name == "" {
+		return Port{}, fmt.Errorf("empty 'name' parameter")
+	}
+	if err := c.requireExtension("extra_dhcp_opt"); err != nil {
+		return Port{}, err
+	}
+
+	// Neutron deletes an option when it is sent with a null value.
+	opt := map[string]interface{}{"opt_name": name, "opt_value": nil}
+	if ipVersion != 0 {
+		opt["ip_version"] = ipVersion
+	}
+	return c.updatePort(portID, nil, map[string]interface{}{
+		"port": map[string]interface{}{
+			"extra_dhcp_opts": []interface{}{opt},
+		},
+	})
+}
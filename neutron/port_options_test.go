@@ -0,0 +1,218 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const portWithOptionsResp = `{
+  "port": {
+    "admin_state_up": true,
+    "allowed_address_pairs": [
+      {
+        "ip_address": "10.0.0.100",
+        "mac_address": "fa:16:3e:a6:50:c1"
+      },
+      {
+        "ip_address": "10.0.0.101",
+        "mac_address": "fa:16:3e:00:00:01"
+      }
+    ],
+    "extra_dhcp_opts": [
+      {
+        "opt_name": "bootfile-name",
+        "opt_value": "pxelinux.0",
+        "ip_version": 4
+      }
+    ],
+    "port_security_enabled": false,
+    "id": "ebe69f1e-bc26-4db5-bed0-c0afb4afe3db",
+    "mac_address": "fa:16:3e:a6:50:c1",
+    "name": "vrrp1",
+    "network_id": "6aeaf34a-c482-4bd3-9dc3-7faf36412f12",
+    "revision_number": 7,
+    "status": "ACTIVE"
+  }
+}`
+
+var _ = Describe("Port options", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		method   string
+		body     []byte
+		requests int
+		ifMatch  string
+		// extensions is served on /v2.0/extensions
+		extensions string
+		// conflicts is the number of updates to fail with 412, as if
+		// another client had changed the port
+		conflicts int
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		body = nil
+		ifMatch = ""
+		conflicts = 0
+		extensions = extensionsResp
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensions)
+				return
+			}
+			requests++
+			method = r.Method
+			if r.Method != http.MethodGet {
+				body, _ = ioutil.ReadAll(r.Body)
+				ifMatch = r.Header.Get("If-Match")
+			}
+			if r.Method == http.MethodPut && conflicts > 0 {
+				conflicts--
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+			fmt.Fprintln(w, portWithOptionsResp)
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reads address pairs, DHCP options and port security", func() {
+		p, err := client.Port("port1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(p.AllowedAddressPairs).To(HaveLen(2))
+		Expect(p.AllowedAddressPairs[0].IPAddress).To(Equal("10.0.0.100"))
+		Expect(p.ExtraDHCPOpts).To(Equal([]neutron.ExtraDHCPOpt{
+			{OptName: "bootfile-name", OptValue: "pxelinux.0", IPVersion: 4},
+		}))
+		Expect(*p.PortSecurityEnabled).To(BeFalse())
+	})
+
+	It("creates a port with address pairs, DHCP options and port security", func() {
+		disabled := false
+		_, err := client.CreatePort(neutron.Port{
+			NetworkID:           "network1",
+			AllowedAddressPairs: []neutron.AddressPair{{IPAddress: "10.0.0.100"}},
+			ExtraDHCPOpts:       []neutron.ExtraDHCPOpt{{OptName: "tftp-server", OptValue: "10.0.0.5"}},
+			PortSecurityEnabled: &disabled,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{
+			"port": {
+				"network_id": "network1",
+				"allowed_address_pairs": [{"ip_address": "10.0.0.100"}],
+				"extra_dhcp_opts": [{"opt_name": "tftp-server", "opt_value": "10.0.0.5"}],
+				"port_security_enabled": false
+			}
+		}`))
+	})
+
+	It("updates port security", func() {
+		enabled := true
+		_, err := client.UpdatePort("port1", neutron.PortUpdate{PortSecurityEnabled: &enabled})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"port": {"port_security_enabled": true}}`))
+	})
+
+	Describe("AddAllowedAddressPair", func() {
+		It("appends to the existing pairs", func() {
+			_, err := client.AddAllowedAddressPair("port1", neutron.AddressPair{IPAddress: "10.0.0.102"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(body).To(MatchJSON(`{
+				"port": {
+					"allowed_address_pairs": [
+						{"ip_address": "10.0.0.100", "mac_address": "fa:16:3e:a6:50:c1"},
+						{"ip_address": "10.0.0.101", "mac_address": "fa:16:3e:00:00:01"},
+						{"ip_address": "10.0.0.102"}
+					]
+				}
+			}`))
+			Expect(ifMatch).To(Equal("revision_number=7"))
+		})
+
+		It("reads the pairs again when the port changed concurrently", func() {
+			conflicts = 1
+			_, err := client.AddAllowedAddressPair("port1", neutron.AddressPair{IPAddress: "10.0.0.102"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(4))
+		})
+
+		It("does not update when the pair is already set", func() {
+			_, err := client.AddAllowedAddressPair("port1", neutron.AddressPair{IPAddress: "10.0.0.100"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(1))
+		})
+
+		It("updates unconditionally without revision-if-match", func() {
+			extensions = `{"extensions": [{"alias": "allowed-address-pairs", "name": "Allowed Address Pairs", "links": []}]}`
+			_, err := client.AddAllowedAddressPair("port1", neutron.AddressPair{IPAddress: "10.0.0.102"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(requests).To(Equal(2))
+			Expect(ifMatch).To(BeEmpty())
+		})
+	})
+
+	Describe("RemoveAllowedAddressPair", func() {
+		It("keeps the other pairs", func() {
+			_, err := client.RemoveAllowedAddressPair("port1", neutron.AddressPair{
+				IPAddress:  "10.0.0.101",
+				MacAddress: "fa:16:3e:00:00:01",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"port": {
+					"allowed_address_pairs": [
+						{"ip_address": "10.0.0.100", "mac_address": "fa:16:3e:a6:50:c1"}
+					]
+				}
+			}`))
+			Expect(ifMatch).To(Equal("revision_number=7"))
+		})
+
+		It("does not update when the pair is not set", func() {
+			_, err := client.RemoveAllowedAddressPair("port1", neutron.AddressPair{IPAddress: "10.0.0.200"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(1))
+		})
+	})
+
+	Describe("SetExtraDHCPOpt", func() {
+		It("sends only the given option", func() {
+			_, err := client.SetExtraDHCPOpt("port1", neutron.ExtraDHCPOpt{OptName: "tftp-server", OptValue: "10.0.0.5"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal(1))
+			Expect(body).To(MatchJSON(`{"port": {"extra_dhcp_opts": [{"opt_name": "tftp-server", "opt_value": "10.0.0.5"}]}}`))
+		})
+
+		It("requires an option name", func() {
+			_, err := client.SetExtraDHCPOpt("port1", neutron.ExtraDHCPOpt{OptValue: "x"})
+			Expect(err).To(MatchError("missing DHCP option name"))
+		})
+	})
+
+	Describe("RemoveExtraDHCPOpt", func() {
+		It("sends the option with a null value", func() {
+			_, err := client.RemoveExtraDHCPOpt("port1", "bootfile-name", 4)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"port": {"extra_dhcp_opts": [{"opt_name": "bootfile-name", "opt_value": null, "ip_version": 4}]}}`))
+		})
+	})
+})
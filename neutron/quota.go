@@ -0,0 +1,168 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type Quota struct {
+	FloatingIP        int `json:"floatingip"`
+	Network           int `json:"network"`
+	Port              int `json:"port"`
+	RBACPolicy        int `json:"rbac_policy"`
+	Router            int `json:"router"`
+	SecurityGroup     int `json:"security_group"`
+	SecurityGroupRule int `json:"security_group_rule"`
+	Subnet            int `json:"subnet"`
+	SubnetPool        int `json:"subnetpool"`
+
+	// Extras holds the limits of resources of other plugins, e.g.
+	// "loadbalancer".
+	Extras Extras `json:"-"`
+}
+
+type SingleQuota struct {
+	Quota Quota `json:"quota"`
+}
+
+type QuotaUsage struct {
+	Limit    int `json:"limit"`
+	Used     int `json:"used"`
+	Reserved int `json:"reserved"`
+}
+
+// Remaining returns how many more of the resource can be allocated, or -1
+// when the quota is unlimited.
+func (u QuotaUsage) Remaining() int {
+	if u.Limit < 0 {
+		return -1
+	}
+	remaining := u.Limit - u.Used - u.Reserved
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+type QuotaDetails struct {
+	FloatingIP        QuotaUsage `json:"floatingip"`
+	Network           QuotaUsage `json:"network"`
+	Port              QuotaUsage `json:"port"`
+	RBACPolicy        QuotaUsage `json:"rbac_policy"`
+	Router            QuotaUsage `json:"router"`
+	SecurityGroup     QuotaUsage `json:"security_group"`
+	SecurityGroupRule QuotaUsage `json:"security_group_rule"`
+	Subnet            QuotaUsage `json:"subnet"`
+	SubnetPool        QuotaUsage `json:"subnetpool"`
+
+	// Extras holds the usage of resources of other plugins, e.g.
+	// "loadbalancer".
+	Extras Extras `json:"-"`
+}
+
+type SingleQuotaDetails struct {
+	Quota QuotaDetails `json:"quota"`
+}
+
+// Usage returns the usage of the named resource, e.g. "port" or "network",
+// including resources of other plugins kept in Extras.
+func (d QuotaDetails) Usage(resource string) (QuotaUsage, error) {
+	switch resource {
+	case "floatingip":
+		return d.FloatingIP, nil
+	case "network":
+		return d.Network, nil
+	case "port":
+		return d.Port, nil
+	case "rbac_policy":
+		return d.RBACPolicy, nil
+	case "router":
+		return d.Router, nil
+	case "security_group":
+		return d.SecurityGroup, nil
+	case "security_group_rule":
+		return d.SecurityGroupRule, nil
+	case "subnet":
+		return d.Subnet, nil
+	case "subnetpool":
+		return d.SubnetPool, nil
+	}
+	if d.Extras.Has(resource) {
+		var u QuotaUsage
+		err := d.Extras.Get(resource, &u)
+		return u, err
+	}
+	return QuotaUsage{}, fmt.Errorf("unknown quota resource '%s'", resource)
+}
+
+// Remaining returns the remaining capacity of the named resource, or -1 when
+// the quota is unlimited.
+func (d QuotaDetails) Remaining(resource string) (int, error) {
+	u, err := d.Usage(resource)
+	if err != nil {
+		return 0, err
+	}
+	return u.Remaining(), nil
+}
+
+func (c *Client) Quota(projectID string) (Quota, error) {
+	if projectID == "" {
+		return Quota{}, fmt.Errorf("empty 'projectID' parameter")
+	}
+	return c.getQuota(fmt.Sprintf("%s/v2.0/quotas/%s", c.URL, projectID))
+}
+
+func (c *Client) DefaultQuota(projectID string) (Quota, error) {
+	if projectID == "" {
+		return Quota{}, fmt.Errorf("empty 'projectID' parameter")
+	}
+	return c.getQuota(fmt.Sprintf("%s/v2.0/quotas/%s/default", c.URL, projectID))
+}
+
+func (c *Client) getQuota(url string) (Quota, error) {
+	var r SingleQuota
+	err := c.send(http.MethodGet, url, nil, http.StatusOK, &r)
+	if err != nil {
+		return Quota{}, err
+	}
+	return r.Quota, nil
+}
+
+// UpdateQuota sets the given limits, keyed by resource name (e.g. "port"),
+// leaving any resource not present in limits unchanged.
+func (c *Client) UpdateQuota(projectID string, limits map[string]int) (Quota, error) {
+	if projectID == "" {
+		return Quota{}, fmt.Errorf("empty 'projectID' parameter")
+	}
+
+	var r SingleQuota
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/quotas/%s", c.URL, projectID), map[string]map[string]int{"quota": limits}, http.StatusOK, &r)
+	if err != nil {
+		return Quota{}, err
+	}
+	return r.Quota, nil
+}
+
+// ResetQuota reverts the project's quota to the defaults.
+func (c *Client) ResetQuota(projectID string) error {
+	if projectID == "" {
+		return fmt.Errorf("empty 'projectID' parameter")
+	}
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/quotas/%s", c.URL, projectID), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) QuotaDetails(projectID string) (QuotaDetails, error) {
+	if projectID == "" {
+		return QuotaDetails{}, fmt.Errorf("empty 'projectID' parameter")
+	}
+	if err := c.requireExtension("quota_details"); err != nil {
+		return QuotaDetails{}, err
+	}
+
+	var r SingleQuotaDetails
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/quotas/%s/details", c.URL, projectID), nil, http.StatusOK, &r)
+	if err != nil {
+		return QuotaDetails{}, err
+	}
+	return r.Quota, nil
+}
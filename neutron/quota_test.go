@@ -0,0 +1,163 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const quotaResp = `{
+  "quota": {
+    "floatingip": 50,
+    "network": 10,
+    "port": 50,
+    "rbac_policy": -1,
+    "router": 10,
+    "security_group": 10,
+    "security_group_rule": 100,
+    "subnet": 10,
+    "subnetpool": -1
+  }
+}`
+
+const quotaDetailsResp = `{
+  "quota": {
+    "floatingip": {"used": 0, "limit": 50, "reserved": 0},
+    "network": {"used": 3, "limit": 10, "reserved": 0},
+    "port": {"used": 45, "limit": 50, "reserved": 2},
+    "rbac_policy": {"used": 0, "limit": -1, "reserved": 0},
+    "router": {"used": 1, "limit": 10, "reserved": 0},
+    "security_group": {"used": 1, "limit": 10, "reserved": 0},
+    "security_group_rule": {"used": 4, "limit": 100, "reserved": 0},
+    "subnet": {"used": 12, "limit": 10, "reserved": 0},
+    "subnetpool": {"used": 0, "limit": -1, "reserved": 0},
+    "loadbalancer": {"used": 2, "limit": 5, "reserved": 1}
+  }
+}`
+
+var _ = Describe("Quotas", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.URL.Path == "/v2.0/extensions":
+				fmt.Fprintln(w, extensionsResp)
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.URL.Path == "/v2.0/quotas/project1/details":
+				fmt.Fprintln(w, quotaDetailsResp)
+			default:
+				fmt.Fprintln(w, quotaResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Quota", func() {
+		It("gets the quota of a project", func() {
+			q, err := client.Quota("project1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/quotas/project1"))
+			Expect(q.Network).To(Equal(10))
+			Expect(q.Port).To(Equal(50))
+			Expect(q.SecurityGroupRule).To(Equal(100))
+			Expect(q.SubnetPool).To(Equal(-1))
+		})
+
+		Context("when project id is invalid", func() {
+			It("returns an error", func() {
+				_, err := client.Quota("")
+				Expect(err).To(MatchError("empty 'projectID' parameter"))
+			})
+		})
+	})
+
+	Describe("DefaultQuota", func() {
+		It("gets the default quota of a project", func() {
+			q, err := client.DefaultQuota("project1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/v2.0/quotas/project1/default"))
+			Expect(q.Router).To(Equal(10))
+		})
+	})
+
+	Describe("UpdateQuota", func() {
+		It("sends only the given limits", func() {
+			q, err := client.UpdateQuota("project1", map[string]int{"port": 0})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodPut))
+			Expect(path).To(Equal("/v2.0/quotas/project1"))
+			Expect(body).To(MatchJSON(`{"quota": {"port": 0}}`))
+			Expect(q.Port).To(Equal(50))
+		})
+	})
+
+	Describe("ResetQuota", func() {
+		It("deletes the project quota", func() {
+			err := client.ResetQuota("project1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(method).To(Equal(http.MethodDelete))
+			Expect(path).To(Equal("/v2.0/quotas/project1"))
+		})
+	})
+
+	Describe("QuotaDetails", func() {
+		var details neutron.QuotaDetails
+
+		BeforeEach(func() {
+			var err error
+			details, err = client.QuotaDetails("project1")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("gets limit, used and reserved per resource", func() {
+			Expect(details.Port).To(Equal(neutron.QuotaUsage{Limit: 50, Used: 45, Reserved: 2}))
+			Expect(details.Network.Used).To(Equal(3))
+		})
+
+		It("computes the remaining capacity of a resource", func() {
+			Expect(details.Remaining("port")).To(Equal(3))
+			Expect(details.Remaining("network")).To(Equal(7))
+		})
+
+		It("reports unlimited resources as -1", func() {
+			Expect(details.Remaining("subnetpool")).To(Equal(-1))
+		})
+
+		It("never reports negative capacity", func() {
+			Expect(details.Remaining("subnet")).To(Equal(0))
+		})
+
+		It("computes the capacity of resources of other plugins", func() {
+			Expect(details.Extras.Has("loadbalancer")).To(BeTrue())
+			Expect(details.Remaining("loadbalancer")).To(Equal(2))
+		})
+
+		It("returns an error for an unknown resource", func() {
+			_, err := details.Remaining("bogus")
+			Expect(err).To(MatchError("unknown quota resource 'bogus'"))
+		})
+	})
+})
@@ -0,0 +1,249 @@
+package neutron
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const revisionIfMatchExtension = "revision-if-match"
+
+// DefaultRevisionRetries is the number of attempts ModifyPort makes before
+// giving up on a port that keeps changing.
+const DefaultRevisionRetries = 5
+
+// PreconditionFailedError is returned by conditional requests when the
+// resource no longer has the expected revision number.
+type PreconditionFailedError struct {
+	Revision int
+	Err      *Error
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("revision_number %d is not the current revision: %v", e.Revision, e.Err)
+}
+
+// IsPreconditionFailed reports whether err is a Neutron 412 response, as
+// returned by conditional requests on a resource that has changed.
+func IsPreconditionFailed(err error) bool {
+	switch e := err.(type) {
+	case *PreconditionFailedError:
+		return true
+	case *Error:
+		return e.StatusCode == http.StatusPreconditionFailed
+	}
+	return false
+}
+
+// parseRevision reads the revision_number attribute of a resource.
+func parseRevision(data []byte) (int, error) {
+	var r struct {
+		RevisionNumber int `json:"revision_number"`
+	}
+	err := json.Unmarshal(data, &r)
+	return r.RevisionNumber, err
+}
+
+func (fip *FloatingIP) UnmarshalJSON(data []byte) error {
+	type plain FloatingIP
+	if err := unmarshalExtras(data, (*plain)(fip), &fip.Extras, "revision_number"); err != nil {
+		return err
+	}
+	var err error
+	fip.RevisionNumber, err = parseRevision(data)
+	return err
+}
+
+func (r *Router) UnmarshalJSON(data []byte) error {
+	type plain Router
+	if err := unmarshalExtras(data, (*plain)(r), &r.Extras, "revision_number"); err != nil {
+		return err
+	}
+	var err error
+	r.RevisionNumber, err = parseRevision(data)
+	return err
+}
+
+func ifMatch(revision int) (http.Header, error) {
+	if revision <= 0 {
+		return nil, fmt.Errorf("invalid revision number %d", revision)
+	}
+	return http.Header{"If-Match": {fmt.Sprintf("revision_number=%d", revision)}}, nil
+}
+
+// conditionalError turns a 412 response to a request conditional on
+// revision into a *PreconditionFailedError.
+func conditionalError(revision int, err error) error {
+	if e, ok := err.(*Error); ok && e.StatusCode == http.StatusPreconditionFailed {
+		return &PreconditionFailedError{Revision: revision, Err: e}
+	}
+	return err
+}
+
+// UpdateNetworkIfMatch updates the network only if its revision number is
+// still revision, returning a *PreconditionFailedError otherwise.
+func (c *Client) UpdateNetworkIfMatch(id string, revision int, u NetworkUpdate) (Network, error) {
+	if id == "" {
+		return Network{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if u.DNSDomain != nil && *u.DNSDomain != "" {
+		if err := ValidateDNSDomain(*u.DNSDomain); err != nil {
+			return Network{}, err
+		}
+	}
+	header, err := ifMatch(revision)
+	if err != nil {
+		return Network{}, err
+	}
+	if err := c.requireExtension(revisionIfMatchExtension); err != nil {
+		return Network{}, err
+	}
+
+	n, err := c.updateNetwork(id, header, u)
+	if err != nil {
+		return Network{}, conditionalError(revision, err)
+	}
+	return n, nil
+}
+
+// UpdateSubnetIfMatch updates the subnet only if its revision number is
+// still revision, returning a *PreconditionFailedError otherwise.
+func (c *Client) UpdateSubnetIfMatch(id string, revision int, u SubnetUpdate) (Subnet, error) {
+	if id == "" {
+		return Subnet{}, fmt.Errorf("empty 'id' parameter")
+	}
+	header, err := ifMatch(revision)
+	if err != nil {
+		return Subnet{}, err
+	}
+	if err := c.requireExtension(revisionIfMatchExtension); err != nil {
+		return Subnet{}, err
+	}
+
+	s, err := c.updateSubnet(id, header, u)
+	if err != nil {
+		return Subnet{}, conditionalError(revision, err)
+	}
+	return s, nil
+}
+
+// UpdatePortIfMatch updates the port only if its revision number is still
+// revision, returning a *PreconditionFailedError otherwise.
+func (c *Client) UpdatePortIfMatch(id string, revision int, u PortUpdate) (Port, error) {
+	if id == "" {
+		return Port{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := u.validateDNS(); err != nil {
+		return Port{}, err
+	}
+	header, err := ifMatch(revision)
+	if err != nil {
+		return Port{}, err
+	}
+	if err := c.requireExtension(revisionIfMatchExtension); err != nil {
+		return Port{}, err
+	}
+
+	p, err := c.updatePort(id, header, map[string]PortUpdate{"port": u})
+	if err != nil {
+		return Port{}, conditionalError(revision, err)
+	}
+	return p, nil
+}
+
+// DeletePortIfMatch deletes the port only if its revision number is still
+// revision, returning a *PreconditionFailedError otherwise.
+func (c *Client) DeletePortIfMatch(id string, revision int) error {
+	return c.deleteIfMatch("ports", id, revision)
+}
+
+// DeleteNetworkIfMatch deletes the network only if its revision number is
+// still revision, returning a *PreconditionFailedError otherwise.
+func (c *Client) DeleteNetworkIfMatch(id string, revision int) error {
+	return c.deleteIfMatch("networks", id, revision)
+}
+
+// DeleteSubnetIfMatch deletes the subnet only if its revision number is
+// still revision, returning a *PreconditionFailedError otherwise.
+func (c *Client) DeleteSubnetIfMatch(id string, revision int) error {
+	return c.deleteIfMatch("subnets", id, revision)
+}
+
+func (c *Client) deleteIfMatch(collection, id string, revision int) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	header, err := ifMatch(revision)
+	if err != nil {
+		return err
+	}
+	if err := c.requireExtension(revisionIfMatchExtension); err != nil {
+		return err
+	}
+
+	err = c.sendHeader(http.MethodDelete, fmt.Sprintf("%s/v2.0/%s/%s", c.URL, collection, id), header, nil, http.StatusNoContent, nil)
+	return conditionalError(revision, err)
+}
+
+// RetryOnPreconditionFailed calls fn until it succeeds, fails with an error
+// other than a precondition failure, or has been called attempts times. fn
+// is always called at least once.
+func RetryOnPreconditionFailed(attempts int, fn func() error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = fn(); !IsPreconditionFailed(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// ModifyPort reads the port, passes it to modify and applies the returned
+// update conditionally on the revision read. When another client changed the
+// port in between, the port is read and modified again, up to
+// DefaultRevisionRetries times. An error is returned when the server does not
+// support conditional requests, as the update could silently overwrite
+// another client's changes.
+func (c *Client) ModifyPort(id string, modify func(Port) (PortUpdate, error)) (Port, error) {
+	return c.modifyPort(id, func(p Port) (*PortUpdate, error) {
+		u, err := modify(p)
+		return &u, err
+	})
+}
+
+// modifyPort is ModifyPort where modify returns a nil update when the port
+// needs no change.
+func (c *Client) modifyPort(id string, modify func(Port) (*PortUpdate, error)) (Port, error) {
+	if err := c.requireExtension(revisionIfMatchExtension); err != nil {
+		return Port{}, err
+	}
+
+	var updated Port
+	err := RetryOnPreconditionFailed(DefaultRevisionRetries, func() error {
+		p, err := c.Port(id)
+		if err != nil {
+			return err
+		}
+		if p.RevisionNumber == 0 {
+			return fmt.Errorf("port '%s' has no revision number", id)
+		}
+		u, err := modify(p)
+		if err != nil {
+			return err
+		}
+		if u == nil {
+			updated = p
+			return nil
+		}
+		updated, err = c.UpdatePortIfMatch(id, p.RevisionNumber, *u)
+		return err
+	})
+	if err != nil {
+		return Port{}, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,240 @@
+package neutron_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revision numbers", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		revision int
+		ifMatch  []string
+		requests []string
+		// concurrent bumps the revision behind the client's back on the
+		// first reads, simulating another controller
+		concurrent int
+		extensions string
+		bodies     []string
+	)
+
+	BeforeEach(func() {
+		revision = 3
+		ifMatch = nil
+		requests = nil
+		concurrent = 0
+		extensions = extensionsResp
+		bodies = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensions)
+				return
+			}
+			requests = append(requests, r.Method)
+			switch r.Method {
+			case http.MethodGet:
+				if revision == 0 {
+					fmt.Fprintln(w, `{"port": {"id": "port1", "name": "web"}}`)
+					return
+				}
+				fmt.Fprintf(w, `{"port": {"id": "port1", "name": "web", "revision_number": %d}}`, revision)
+				if concurrent > 0 {
+					concurrent--
+					revision++
+				}
+				return
+			}
+
+			bodies = append(bodies, r.URL.Path)
+			ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+			if r.Header.Get("If-Match") != "" && r.Header.Get("If-Match") != fmt.Sprintf("revision_number=%d", revision) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				fmt.Fprintln(w, `{"NeutronError": {"type": "RevisionNumberConstraintFailed"}}`)
+				return
+			}
+			revision++
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			var in map[string]map[string]interface{}
+			json.Unmarshal(body, &in)
+			for _, res := range in {
+				res["revision_number"] = revision
+			}
+			json.NewEncoder(w).Encode(in)
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reads the revision number", func() {
+		p, err := client.Port("port1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(p.RevisionNumber).To(Equal(3))
+	})
+
+	It("never sends the revision number", func() {
+		var r neutron.SingleNetwork
+		err := json.Unmarshal([]byte(`{"network": {"name": "net1", "admin_state_up": true, "revision_number": 4}}`), &r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.Network.RevisionNumber).To(Equal(4))
+		Expect(r.Network.Extras).To(BeEmpty())
+		Expect(json.Marshal(r)).To(MatchJSON(`{"network": {"name": "net1", "admin_state_up": true}}`))
+
+		var fip neutron.SingleFloatingIP
+		err = json.Unmarshal([]byte(`{"floatingip": {"floating_network_id": "ext1", "revision_number": 2}}`), &fip)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fip.FloatingIP.RevisionNumber).To(Equal(2))
+		Expect(json.Marshal(fip)).ToNot(ContainSubstring("revision_number"))
+	})
+
+	It("sends If-Match on conditional updates", func() {
+		name := "api"
+		p, err := client.UpdatePortIfMatch("port1", 3, neutron.PortUpdate{Name: &name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ifMatch).To(Equal([]string{"revision_number=3"}))
+		Expect(p.RevisionNumber).To(Equal(4))
+	})
+
+	It("returns a precondition failed error on stale revisions", func() {
+		name := "api"
+		_, err := client.UpdatePortIfMatch("port1", 2, neutron.PortUpdate{Name: &name})
+		Expect(neutron.IsPreconditionFailed(err)).To(BeTrue())
+
+		pfErr, ok := err.(*neutron.PreconditionFailedError)
+		Expect(ok).To(BeTrue())
+		Expect(pfErr.Revision).To(Equal(2))
+		Expect(pfErr.Err.StatusCode).To(Equal(http.StatusPreconditionFailed))
+	})
+
+	It("updates networks and subnets conditionally", func() {
+		name := "backend"
+		n, err := client.UpdateNetworkIfMatch("net1", 3, neutron.NetworkUpdate{Name: &name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n.Name).To(Equal("backend"))
+		Expect(n.RevisionNumber).To(Equal(4))
+
+		dhcp := false
+		_, err = client.UpdateSubnetIfMatch("subnet1", 3, neutron.SubnetUpdate{EnableDHCP: &dhcp})
+		Expect(neutron.IsPreconditionFailed(err)).To(BeTrue())
+
+		s, err := client.UpdateSubnetIfMatch("subnet1", 4, neutron.SubnetUpdate{EnableDHCP: &dhcp})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.EnableDHCP).To(BeFalse())
+		Expect(s.RevisionNumber).To(Equal(5))
+
+		Expect(bodies).To(Equal([]string{"/v2.0/networks/net1", "/v2.0/subnets/subnet1", "/v2.0/subnets/subnet1"}))
+		Expect(ifMatch).To(Equal([]string{"revision_number=3", "revision_number=3", "revision_number=4"}))
+	})
+
+	It("updates networks and subnets unconditionally", func() {
+		mtu := 9000
+		_, err := client.UpdateNetwork("net1", neutron.NetworkUpdate{MTU: &mtu})
+		Expect(err).ToNot(HaveOccurred())
+
+		gateway := "10.0.0.254"
+		_, err = client.UpdateSubnet("subnet1", neutron.SubnetUpdate{GatewayIP: &gateway})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ifMatch).To(Equal([]string{"", ""}))
+	})
+
+	It("validates the DNS domain of network updates", func() {
+		domain := "example..org."
+		_, err := client.UpdateNetworkIfMatch("net1", 3, neutron.NetworkUpdate{DNSDomain: &domain})
+		Expect(err).To(HaveOccurred())
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("deletes conditionally", func() {
+		err := client.DeletePortIfMatch("port1", 1)
+		Expect(neutron.IsPreconditionFailed(err)).To(BeTrue())
+
+		err = client.DeletePortIfMatch("port1", 3)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = client.DeleteSubnetIfMatch("subnet1", 4)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ifMatch).To(Equal([]string{"revision_number=1", "revision_number=3", "revision_number=4"}))
+		Expect(bodies).To(Equal([]string{"/v2.0/ports/port1", "/v2.0/ports/port1", "/v2.0/subnets/subnet1"}))
+	})
+
+	It("rejects invalid revisions", func() {
+		err := client.DeleteNetworkIfMatch("net1", 0)
+		Expect(err).To(MatchError("invalid revision number 0"))
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("retries read-modify-write on conflicts", func() {
+		concurrent = 2
+		var seen []string
+		p, err := client.ModifyPort("port1", func(p neutron.Port) (neutron.PortUpdate, error) {
+			seen = append(seen, p.Name)
+			name := p.Name + "-v2"
+			return neutron.PortUpdate{Name: &name}, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(seen).To(HaveLen(3))
+		Expect(ifMatch).To(Equal([]string{"revision_number=3", "revision_number=4", "revision_number=5"}))
+		Expect(p.Name).To(Equal("web-v2"))
+	})
+
+	It("gives up after the retry limit", func() {
+		concurrent = 100
+		_, err := client.ModifyPort("port1", func(p neutron.Port) (neutron.PortUpdate, error) {
+			return neutron.PortUpdate{}, nil
+		})
+		Expect(neutron.IsPreconditionFailed(err)).To(BeTrue())
+		Expect(ifMatch).To(HaveLen(neutron.DefaultRevisionRetries))
+	})
+
+	It("always calls the function at least once", func() {
+		calls := 0
+		err := neutron.RetryOnPreconditionFailed(0, func() error {
+			calls++
+			return errors.New("boom")
+		})
+		Expect(err).To(MatchError("boom"))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("refuses to modify a port without revision numbers", func() {
+		revision = 0
+		called := false
+		_, err := client.ModifyPort("port1", func(p neutron.Port) (neutron.PortUpdate, error) {
+			called = true
+			return neutron.PortUpdate{}, nil
+		})
+		Expect(err).To(MatchError("port 'port1' has no revision number"))
+		Expect(called).To(BeFalse())
+		Expect(requests).To(Equal([]string{http.MethodGet}))
+	})
+
+	It("requires the revision-if-match extension", func() {
+		extensions = `{"extensions": []}`
+		_, err := client.ModifyPort("port1", func(p neutron.Port) (neutron.PortUpdate, error) {
+			return neutron.PortUpdate{}, nil
+		})
+		Expect(neutron.IsExtensionUnsupported(err)).To(BeTrue())
+
+		err = client.DeletePortIfMatch("port1", 3)
+		Expect(neutron.IsExtensionUnsupported(err)).To(BeTrue())
+		Expect(requests).To(BeEmpty())
+	})
+})
@@ -0,0 +1,95 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type Router struct {
+	ID                  string               `json:"id,omitempty"`
+	Name                string               `json:"name,omitempty"`
+	Description         string               `json:"description,omitempty"`
+	Status              string               `json:"status,omitempty"`
+	AdminStateUp        bool                 `json:"admin_state_up"`
+	TenantID            string               `json:"tenant_id,omitempty"`
+	ProjectID           string               `json:"project_id,omitempty"`
+	ExternalGatewayInfo *ExternalGatewayInfo `json:"external_gateway_info,omitempty"`
+	Distributed         bool                 `json:"distributed,omitempty"`
+	HA                  bool                 `json:"ha,omitempty"`
+	FlavorID            string               `json:"flavor_id,omitempty"`
+	Tags                []string             `json:"tags,omitempty"`
+
+	// AvailabilityZoneHints are the zones requested for the L3 agents of the
+	// router; AvailabilityZones are the zones Neutron scheduled it to.
+	AvailabilityZoneHints []string `json:"availability_zone_hints,omitempty"`
+	AvailabilityZones     []string `json:"availability_zones,omitempty"`
+
+	// RevisionNumber is read only; it is never sent to Neutron.
+	RevisionNumber int `json:"-"`
+
+	Extras Extras `json:"-"`
+}
+
+type ExternalGatewayInfo struct {
+	NetworkID        string    `json:"network_id"`
+	EnableSNAT       *bool     `json:"enable_snat,omitempty"`
+	ExternalFixedIPs []FixedIP `json:"external_fixed_ips,omitempty"`
+}
+
+type GetRouters struct {
+	Routers []Router `json:"routers"`
+}
+
+type SingleRouter struct {
+	Router Router `json:"router"`
+}
+
+func (c *Client) Routers(opts ...ListOpts) ([]Router, error) {
+	var r GetRouters
+	err := c.send(http.MethodGet, c.listURL("routers", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Routers, nil
+}
+
+func (c *Client) Router(id string) (Router, error) {
+	if id == "" {
+		return Router{}, fmt.Errorf("empty 'id' parameter")
+	}
+
+	var r SingleRouter
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/routers/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return Router{}, err
+	}
+	return r.Router, nil
+}
+
+func (c *Client) CreateRouter(router Router) (Router, error) {
+	if len(router.AvailabilityZoneHints) > 0 {
+		if err := c.requireExtension("router_availability_zone"); err != nil {
+			return Router{}, err
+		}
+	}
+	if router.FlavorID != "" {
+		if err := c.requireExtension("l3-flavors"); err != nil {
+			return Router{}, err
+		}
+	}
+
+	var r SingleRouter
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/routers", c.URL), SingleRouter{Router: router}, http.StatusCreated, &r)
+	if err != nil {
+		return Router{}, err
+	}
+	return r.Router, nil
+}
+
+func (c *Client) DeleteRouter(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/routers/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
@@ -0,0 +1,218 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+const (
+	NetworkTypeVLAN   = "vlan"
+	NetworkTypeVXLAN  = "vxlan"
+	NetworkTypeGRE    = "gre"
+	NetworkTypeGeneve = "geneve"
+)
+
+// NetworkSegmentRange is a range of segmentation IDs, shared or reserved for
+// a project. Used maps each allocated ID, as a string, to the project using
+// it and Available lists the IDs still free. Default, Used and Available are
+// read only; they are never sent to Neutron.
+type NetworkSegmentRange struct {
+	ID              string            `json:"id,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Default         bool              `json:"default,omitempty"`
+	Shared          bool              `json:"shared"`
+	NetworkType     string            `json:"network_type,omitempty"`
+	PhysicalNetwork string            `json:"physical_network,omitempty"`
+	Minimum         int               `json:"minimum,omitempty"`
+	Maximum         int               `json:"maximum,omitempty"`
+	Used            map[string]string `json:"used,omitempty"`
+	Available       []int             `json:"available,omitempty"`
+	TenantID        string            `json:"tenant_id,omitempty"`
+	ProjectID       string            `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetNetworkSegmentRanges struct {
+	NetworkSegmentRanges []NetworkSegmentRange `json:"network_segment_ranges"`
+}
+
+type SingleNetworkSegmentRange struct {
+	NetworkSegmentRange NetworkSegmentRange `json:"network_segment_range"`
+}
+
+// NetworkSegmentRangeUpdate holds the segment range attributes to change;
+// nil fields are left as is.
+type NetworkSegmentRangeUpdate struct {
+	Name    *string `json:"name,omitempty"`
+	Minimum *int    `json:"minimum,omitempty"`
+	Maximum *int    `json:"maximum,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// SegmentationIDRange is the inclusive range of segmentation IDs First to
+// Last.
+type SegmentationIDRange struct {
+	First int
+	Last  int
+}
+
+// Len returns the number of IDs in the range.
+func (r SegmentationIDRange) Len() int {
+	return r.Last - r.First + 1
+}
+
+// SegmentationIDUsage reports the allocation of the IDs of a segment range.
+// Used is sorted and UsedBy maps each used ID to its project. Available holds
+// the free IDs as sorted, non adjacent ranges, so that a VXLAN range of
+// millions of IDs is not expanded; AvailableCount is the number of free IDs.
+type SegmentationIDUsage struct {
+	Total          int
+	Used           []int
+	UsedBy         map[int]string
+	Available      []SegmentationIDRange
+	AvailableCount int
+}
+
+// Usage returns the used and available segmentation IDs of the range.
+func (r NetworkSegmentRange) Usage() (SegmentationIDUsage, error) {
+	if r.Maximum < r.Minimum {
+		return SegmentationIDUsage{}, fmt.Errorf("invalid segment range %d-%d", r.Minimum, r.Maximum)
+	}
+
+	u := SegmentationIDUsage{
+		Total:  r.Maximum - r.Minimum + 1,
+		UsedBy: map[int]string{},
+	}
+	for s, project := range r.Used {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return SegmentationIDUsage{}, fmt.Errorf("invalid segmentation id '%s'", s)
+		}
+		u.Used = append(u.Used, id)
+		u.UsedBy[id] = project
+	}
+	sort.Ints(u.Used)
+
+	if r.Available != nil {
+		available := append([]int(nil), r.Available...)
+		sort.Ints(available)
+		for _, id := range available {
+			u.Available = appendRange(u.Available, SegmentationIDRange{First: id, Last: id})
+		}
+	} else {
+		// the gaps between the used IDs within the range are free
+		next := r.Minimum
+		for _, id := range u.Used {
+			if id < next || id > r.Maximum {
+				continue
+			}
+			if id > next {
+				u.Available = append(u.Available, SegmentationIDRange{First: next, Last: id - 1})
+			}
+			next = id + 1
+		}
+		if next <= r.Maximum {
+			u.Available = append(u.Available, SegmentationIDRange{First: next, Last: r.Maximum})
+		}
+	}
+	for _, a := range u.Available {
+		u.AvailableCount += a.Len()
+	}
+	return u, nil
+}
+
+// appendRange appends the range to the sorted ranges, merging it with the last
+// one when they overlap or are adjacent.
+func appendRange(ranges []SegmentationIDRange, r SegmentationIDRange) []SegmentationIDRange {
+	if n := len(ranges); n > 0 && r.First <= ranges[n-1].Last+1 {
+		if r.Last > ranges[n-1].Last {
+			ranges[n-1].Last = r.Last
+		}
+		return ranges
+	}
+	return append(ranges, r)
+}
+
+const segmentRangeExtension = "network-segment-range"
+
+func (c *Client) NetworkSegmentRanges(opts ...ListOpts) ([]NetworkSegmentRange, error) {
+	if err := c.requireExtension(segmentRangeExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetNetworkSegmentRanges
+	err := c.send(http.MethodGet, c.listURL("network_segment_ranges", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.NetworkSegmentRanges, nil
+}
+
+func (c *Client) NetworkSegmentRange(id string) (NetworkSegmentRange, error) {
+	if id == "" {
+		return NetworkSegmentRange{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(segmentRangeExtension); err != nil {
+		return NetworkSegmentRange{}, err
+	}
+
+	var r SingleNetworkSegmentRange
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/network_segment_ranges/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return NetworkSegmentRange{}, err
+	}
+	return r.NetworkSegmentRange, nil
+}
+
+func (c *Client) CreateNetworkSegmentRange(sr NetworkSegmentRange) (NetworkSegmentRange, error) {
+	switch {
+	case sr.NetworkType == "":
+		return NetworkSegmentRange{}, fmt.Errorf("missing network type")
+	case sr.Minimum <= 0 || sr.Maximum < sr.Minimum:
+		return NetworkSegmentRange{}, fmt.Errorf("invalid segment range %d-%d", sr.Minimum, sr.Maximum)
+	case !sr.Shared && sr.ProjectID == "":
+		return NetworkSegmentRange{}, fmt.Errorf("missing project id for a non shared range")
+	}
+	if err := c.requireExtension(segmentRangeExtension); err != nil {
+		return NetworkSegmentRange{}, err
+	}
+
+	var r SingleNetworkSegmentRange
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/network_segment_ranges", c.URL), SingleNetworkSegmentRange{NetworkSegmentRange: sr}, http.StatusCreated, &r)
+	if err != nil {
+		return NetworkSegmentRange{}, err
+	}
+	return r.NetworkSegmentRange, nil
+}
+
+func (c *Client) UpdateNetworkSegmentRange(id string, u NetworkSegmentRangeUpdate) (NetworkSegmentRange, error) {
+	if id == "" {
+		return NetworkSegmentRange{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(segmentRangeExtension); err != nil {
+		return NetworkSegmentRange{}, err
+	}
+
+	var r SingleNetworkSegmentRange
+	body := map[string]NetworkSegmentRangeUpdate{"network_segment_range": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/network_segment_ranges/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return NetworkSegmentRange{}, err
+	}
+	return r.NetworkSegmentRange, nil
+}
+
+func (c *Client) DeleteNetworkSegmentRange(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(segmentRangeExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/network_segment_ranges/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
@@ -0,0 +1,160 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const networkSegmentRangeResp = `{
+  "network_segment_range": {
+    "id": "81b5c8b6-2c4c-4e5c-9a9f-4f8e1d2c3b4a",
+    "name": "project1-vlans",
+    "default": false,
+    "shared": false,
+    "project_id": "7011dc7fccac4efda89dc3b7f0d9fe63",
+    "network_type": "vlan",
+    "physical_network": "physnet1",
+    "minimum": 100,
+    "maximum": 104,
+    "used": {"101": "7011dc7fccac4efda89dc3b7f0d9fe63", "103": "7011dc7fccac4efda89dc3b7f0d9fe63"},
+    "available": [104, 100, 102]
+  }
+}`
+
+var _ = Describe("Network segment ranges", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		path     string
+		body     []byte
+		requests int
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests++
+			path = r.URL.Path
+			body, _ = ioutil.ReadAll(r.Body)
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+			fmt.Fprintln(w, networkSegmentRangeResp)
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("creates a project range", func() {
+		_, err := client.CreateNetworkSegmentRange(neutron.NetworkSegmentRange{
+			Name:            "project1-vlans",
+			NetworkType:     neutron.NetworkTypeVLAN,
+			PhysicalNetwork: "physnet1",
+			Minimum:         100,
+			Maximum:         104,
+			ProjectID:       "7011dc7fccac4efda89dc3b7f0d9fe63",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/network_segment_ranges"))
+		Expect(body).To(MatchJSON(`{
+			"network_segment_range": {
+				"name": "project1-vlans",
+				"shared": false,
+				"network_type": "vlan",
+				"physical_network": "physnet1",
+				"minimum": 100,
+				"maximum": 104,
+				"project_id": "7011dc7fccac4efda89dc3b7f0d9fe63"
+			}
+		}`))
+	})
+
+	It("never sends read only attributes", func() {
+		r, err := client.NetworkSegmentRange("81b5c8b6-2c4c-4e5c-9a9f-4f8e1d2c3b4a")
+		Expect(err).ToNot(HaveOccurred())
+		r.ID, r.Default = "", true
+
+		_, err = client.CreateNetworkSegmentRange(r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{
+			"network_segment_range": {
+				"name": "project1-vlans",
+				"shared": false,
+				"network_type": "vlan",
+				"physical_network": "physnet1",
+				"minimum": 100,
+				"maximum": 104,
+				"project_id": "7011dc7fccac4efda89dc3b7f0d9fe63"
+			}
+		}`))
+	})
+
+	It("requires a project for non shared ranges", func() {
+		_, err := client.CreateNetworkSegmentRange(neutron.NetworkSegmentRange{
+			NetworkType: neutron.NetworkTypeVXLAN,
+			Minimum:     1000,
+			Maximum:     2000,
+		})
+		Expect(err).To(MatchError("missing project id for a non shared range"))
+		Expect(requests).To(Equal(0))
+	})
+
+	It("reports used and available segmentation ids", func() {
+		r, err := client.NetworkSegmentRange("81b5c8b6-2c4c-4e5c-9a9f-4f8e1d2c3b4a")
+		Expect(err).ToNot(HaveOccurred())
+
+		u, err := r.Usage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.Total).To(Equal(5))
+		Expect(u.Used).To(Equal([]int{101, 103}))
+		Expect(u.Available).To(Equal([]neutron.SegmentationIDRange{{First: 100, Last: 100}, {First: 102, Last: 102}, {First: 104, Last: 104}}))
+		Expect(u.AvailableCount).To(Equal(3))
+		Expect(u.UsedBy[103]).To(Equal("7011dc7fccac4efda89dc3b7f0d9fe63"))
+	})
+
+	It("derives available ids from the range when not reported", func() {
+		r := neutron.NetworkSegmentRange{Minimum: 10, Maximum: 13, Used: map[string]string{"11": "p1"}}
+		u, err := r.Usage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.Available).To(Equal([]neutron.SegmentationIDRange{{First: 10, Last: 10}, {First: 12, Last: 13}}))
+		Expect(u.AvailableCount).To(Equal(3))
+	})
+
+	It("merges the reported available ids into ranges", func() {
+		r := neutron.NetworkSegmentRange{Minimum: 1, Maximum: 10, Available: []int{7, 2, 3, 9, 4, 8}}
+		u, err := r.Usage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.Available).To(Equal([]neutron.SegmentationIDRange{{First: 2, Last: 4}, {First: 7, Last: 9}}))
+		Expect(u.AvailableCount).To(Equal(6))
+	})
+
+	It("counts large ranges without expanding them", func() {
+		r := neutron.NetworkSegmentRange{
+			NetworkType: neutron.NetworkTypeVXLAN,
+			Minimum:     1,
+			Maximum:     1<<24 - 1,
+			Used:        map[string]string{"1": "p1", "5000": "p2", "16777215": "p3", "20000000": "p4"},
+		}
+		u, err := r.Usage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.Total).To(Equal(1<<24 - 1))
+		Expect(u.Available).To(Equal([]neutron.SegmentationIDRange{{First: 2, Last: 4999}, {First: 5001, Last: 16777214}}))
+		Expect(u.AvailableCount).To(Equal(1<<24 - 4))
+	})
+})
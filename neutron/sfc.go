@@ -0,0 +1,660 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// PortPair is a service function instance, given by the ports traffic enters
+// and leaves it through. Both may be the same port.
+type PortPair struct {
+	ID                        string                 `json:"id,omitempty"`
+	Name                      string                 `json:"name,omitempty"`
+	Description               string                 `json:"description,omitempty"`
+	Ingress                   string                 `json:"ingress,omitempty"`
+	Egress                    string                 `json:"egress,omitempty"`
+	ServiceFunctionParameters map[string]interface{} `json:"service_function_parameters,omitempty"`
+	TenantID                  string                 `json:"tenant_id,omitempty"`
+	ProjectID                 string                 `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetPortPairs struct {
+	PortPairs []PortPair `json:"port_pairs"`
+}
+
+type SinglePortPair struct {
+	PortPair PortPair `json:"port_pair"`
+}
+
+// PortPairUpdate holds the port pair attributes to change; nil fields are
+// left as is.
+type PortPairUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// PortPairGroup load balances traffic over port pairs providing the same
+// service function.
+type PortPairGroup struct {
+	ID                      string                 `json:"id,omitempty"`
+	Name                    string                 `json:"name,omitempty"`
+	Description             string                 `json:"description,omitempty"`
+	PortPairs               []string               `json:"port_pairs"`
+	PortPairGroupParameters map[string]interface{} `json:"port_pair_group_parameters,omitempty"`
+	TapEnabled              bool                   `json:"tap_enabled,omitempty"`
+	TenantID                string                 `json:"tenant_id,omitempty"`
+	ProjectID               string                 `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetPortPairGroups struct {
+	PortPairGroups []PortPairGroup `json:"port_pair_groups"`
+}
+
+type SinglePortPairGroup struct {
+	PortPairGroup PortPairGroup `json:"port_pair_group"`
+}
+
+// PortPairGroupUpdate holds the port pair group attributes to change; nil
+// fields are left as is.
+type PortPairGroupUpdate struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	PortPairs   *[]string `json:"port_pairs,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// FlowClassifier selects the traffic steered into a port chain.
+type FlowClassifier struct {
+	ID                      string                 `json:"id,omitempty"`
+	Name                    string                 `json:"name,omitempty"`
+	Description             string                 `json:"description,omitempty"`
+	EtherType               string                 `json:"ethertype,omitempty"`
+	Protocol                string                 `json:"protocol,omitempty"`
+	SourcePortRangeMin      int                    `json:"source_port_range_min,omitempty"`
+	SourcePortRangeMax      int                    `json:"source_port_range_max,omitempty"`
+	DestinationPortRangeMin int                    `json:"destination_port_range_min,omitempty"`
+	DestinationPortRangeMax int                    `json:"destination_port_range_max,omitempty"`
+	SourceIPPrefix          string                 `json:"source_ip_prefix,omitempty"`
+	DestinationIPPrefix     string                 `json:"destination_ip_prefix,omitempty"`
+	LogicalSourcePort       string                 `json:"logical_source_port,omitempty"`
+	LogicalDestinationPort  string                 `json:"logical_destination_port,omitempty"`
+	L7Parameters            map[string]interface{} `json:"l7_parameters,omitempty"`
+	TenantID                string                 `json:"tenant_id,omitempty"`
+	ProjectID               string                 `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetFlowClassifiers struct {
+	FlowClassifiers []FlowClassifier `json:"flow_classifiers"`
+}
+
+type SingleFlowClassifier struct {
+	FlowClassifier FlowClassifier `json:"flow_classifier"`
+}
+
+// FlowClassifierUpdate holds the flow classifier attributes to change; nil
+// fields are left as is.
+type FlowClassifierUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// PortChainParameters are set when the chain is created and cannot be
+// changed afterwards.
+type PortChainParameters struct {
+	Correlation string `json:"correlation,omitempty"`
+	Symmetric   bool   `json:"symmetric,omitempty"`
+}
+
+// PortChain steers the traffic matched by its flow classifiers through its
+// port pair groups, in order.
+type PortChain struct {
+	ID              string               `json:"id,omitempty"`
+	Name            string               `json:"name,omitempty"`
+	Description     string               `json:"description,omitempty"`
+	PortPairGroups  []string             `json:"port_pair_groups"`
+	FlowClassifiers []string             `json:"flow_classifiers,omitempty"`
+	ChainParameters *PortChainParameters `json:"chain_parameters,omitempty"`
+	ChainID         int                  `json:"chain_id,omitempty"`
+	TenantID        string               `json:"tenant_id,omitempty"`
+	ProjectID       string               `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetPortChains struct {
+	PortChains []PortChain `json:"port_chains"`
+}
+
+type SinglePortChain struct {
+	PortChain PortChain `json:"port_chain"`
+}
+
+// PortChainUpdate holds the port chain attributes to change; nil fields are
+// left as is.
+type PortChainUpdate struct {
+	Name            *string   `json:"name,omitempty"`
+	Description     *string   `json:"description,omitempty"`
+	PortPairGroups  *[]string `json:"port_pair_groups,omitempty"`
+	FlowClassifiers *[]string `json:"flow_classifiers,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// ServiceGraph links port chains: PortChains maps the id of a chain to the
+// ids of the chains its traffic continues into.
+type ServiceGraph struct {
+	ID          string              `json:"id,omitempty"`
+	Name        string              `json:"name,omitempty"`
+	Description string              `json:"description,omitempty"`
+	PortChains  map[string][]string `json:"port_chains,omitempty"`
+	TenantID    string              `json:"tenant_id,omitempty"`
+	ProjectID   string              `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetServiceGraphs struct {
+	ServiceGraphs []ServiceGraph `json:"service_graphs"`
+}
+
+type SingleServiceGraph struct {
+	ServiceGraph ServiceGraph `json:"service_graph"`
+}
+
+// ServiceGraphUpdate holds the service graph attributes to change; nil
+// fields are left as is.
+type ServiceGraphUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+const (
+	sfcExtension            = "sfc"
+	flowClassifierExtension = "flow_classifier"
+	serviceGraphExtension   = "service_graph"
+)
+
+func (c *Client) PortPairs(opts ...ListOpts) ([]PortPair, error) {
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetPortPairs
+	err := c.send(http.MethodGet, c.listURL("sfc/port_pairs", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.PortPairs, nil
+}
+
+func (c *Client) PortPair(id string) (PortPair, error) {
+	if id == "" {
+		return PortPair{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return PortPair{}, err
+	}
+
+	var r SinglePortPair
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/sfc/port_pairs/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return PortPair{}, err
+	}
+	return r.PortPair, nil
+}
+
+func (c *Client) CreatePortPair(pp PortPair) (PortPair, error) {
+	if pp.Ingress == "" || pp.Egress == "" {
+		return PortPair{}, fmt.Errorf("missing port pair ingress or egress port")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return PortPair{}, err
+	}
+
+	var r SinglePortPair
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/sfc/port_pairs", c.URL), SinglePortPair{PortPair: pp}, http.StatusCreated, &r)
+	if err != nil {
+		return PortPair{}, err
+	}
+	return r.PortPair, nil
+}
+
+func (c *Client) UpdatePortPair(id string, u PortPairUpdate) (PortPair, error) {
+	if id == "" {
+		return PortPair{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return PortPair{}, err
+	}
+
+	var r SinglePortPair
+	body := map[string]PortPairUpdate{"port_pair": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/sfc/port_pairs/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return PortPair{}, err
+	}
+	return r.PortPair, nil
+}
+
+func (c *Client) DeletePortPair(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/sfc/port_pairs/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) PortPairGroups(opts ...ListOpts) ([]PortPairGroup, error) {
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetPortPairGroups
+	err := c.send(http.MethodGet, c.listURL("sfc/port_pair_groups", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.PortPairGroups, nil
+}
+
+func (c *Client) PortPairGroup(id string) (PortPairGroup, error) {
+	if id == "" {
+		return PortPairGroup{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return PortPairGroup{}, err
+	}
+
+	var r SinglePortPairGroup
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/sfc/port_pair_groups/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return PortPairGroup{}, err
+	}
+	return r.PortPairGroup, nil
+}
+
+func (c *Client) CreatePortPairGroup(g PortPairGroup) (PortPairGroup, error) {
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return PortPairGroup{}, err
+	}
+
+	var r SinglePortPairGroup
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/sfc/port_pair_groups", c.URL), SinglePortPairGroup{PortPairGroup: g}, http.StatusCreated, &r)
+	if err != nil {
+		return PortPairGroup{}, err
+	}
+	return r.PortPairGroup, nil
+}
+
+func (c *Client) UpdatePortPairGroup(id string, u PortPairGroupUpdate) (PortPairGroup, error) {
+	if id == "" {
+		return PortPairGroup{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return PortPairGroup{}, err
+	}
+
+	var r SinglePortPairGroup
+	body := map[string]PortPairGroupUpdate{"port_pair_group": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/sfc/port_pair_groups/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return PortPairGroup{}, err
+	}
+	return r.PortPairGroup, nil
+}
+
+func (c *Client) DeletePortPairGroup(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/sfc/port_pair_groups/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) FlowClassifiers(opts ...ListOpts) ([]FlowClassifier, error) {
+	if err := c.requireExtension(flowClassifierExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetFlowClassifiers
+	err := c.send(http.MethodGet, c.listURL("sfc/flow_classifiers", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.FlowClassifiers, nil
+}
+
+func (c *Client) FlowClassifier(id string) (FlowClassifier, error) {
+	if id == "" {
+		return FlowClassifier{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(flowClassifierExtension); err != nil {
+		return FlowClassifier{}, err
+	}
+
+	var r SingleFlowClassifier
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/sfc/flow_classifiers/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return FlowClassifier{}, err
+	}
+	return r.FlowClassifier, nil
+}
+
+func (c *Client) CreateFlowClassifier(fc FlowClassifier) (FlowClassifier, error) {
+	if err := c.requireExtension(flowClassifierExtension); err != nil {
+		return FlowClassifier{}, err
+	}
+
+	var r SingleFlowClassifier
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/sfc/flow_classifiers", c.URL), SingleFlowClassifier{FlowClassifier: fc}, http.StatusCreated, &r)
+	if err != nil {
+		return FlowClassifier{}, err
+	}
+	return r.FlowClassifier, nil
+}
+
+func (c *Client) UpdateFlowClassifier(id string, u FlowClassifierUpdate) (FlowClassifier, error) {
+	if id == "" {
+		return FlowClassifier{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(flowClassifierExtension); err != nil {
+		return FlowClassifier{}, err
+	}
+
+	var r SingleFlowClassifier
+	body := map[string]FlowClassifierUpdate{"flow_classifier": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/sfc/flow_classifiers/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return FlowClassifier{}, err
+	}
+	return r.FlowClassifier, nil
+}
+
+func (c *Client) DeleteFlowClassifier(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(flowClassifierExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/sfc/flow_classifiers/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) PortChains(opts ...ListOpts) ([]PortChain, error) {
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetPortChains
+	err := c.send(http.MethodGet, c.listURL("sfc/port_chains", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.PortChains, nil
+}
+
+func (c *Client) PortChain(id string) (PortChain, error) {
+	if id == "" {
+		return PortChain{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return PortChain{}, err
+	}
+
+	var r SinglePortChain
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/sfc/port_chains/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return PortChain{}, err
+	}
+	return r.PortChain, nil
+}
+
+func (c *Client) CreatePortChain(pc PortChain) (PortChain, error) {
+	if len(pc.PortPairGroups) == 0 {
+		return PortChain{}, fmt.Errorf("missing port pair groups")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return PortChain{}, err
+	}
+
+	var r SinglePortChain
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/sfc/port_chains", c.URL), SinglePortChain{PortChain: pc}, http.StatusCreated, &r)
+	if err != nil {
+		return PortChain{}, err
+	}
+	return r.PortChain, nil
+}
+
+func (c *Client) UpdatePortChain(id string, u PortChainUpdate) (PortChain, error) {
+	if id == "" {
+		return PortChain{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return PortChain{}, err
+	}
+
+	var r SinglePortChain
+	body := map[string]PortChainUpdate{"port_chain": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/sfc/port_chains/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return PortChain{}, err
+	}
+	return r.PortChain, nil
+}
+
+func (c *Client) DeletePortChain(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/sfc/port_chains/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) ServiceGraphs(opts ...ListOpts) ([]ServiceGraph, error) {
+	if err := c.requireExtension(serviceGraphExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetServiceGraphs
+	err := c.send(http.MethodGet, c.listURL("sfc/service_graphs", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.ServiceGraphs, nil
+}
+
+func (c *Client) ServiceGraph(id string) (ServiceGraph, error) {
+	if id == "" {
+		return ServiceGraph{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(serviceGraphExtension); err != nil {
+		return ServiceGraph{}, err
+	}
+
+	var r SingleServiceGraph
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/sfc/service_graphs/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return ServiceGraph{}, err
+	}
+	return r.ServiceGraph, nil
+}
+
+func (c *Client) CreateServiceGraph(g ServiceGraph) (ServiceGraph, error) {
+	if err := c.requireExtension(serviceGraphExtension); err != nil {
+		return ServiceGraph{}, err
+	}
+
+	var r SingleServiceGraph
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/sfc/service_graphs", c.URL), SingleServiceGraph{ServiceGraph: g}, http.StatusCreated, &r)
+	if err != nil {
+		return ServiceGraph{}, err
+	}
+	return r.ServiceGraph, nil
+}
+
+func (c *Client) UpdateServiceGraph(id string, u ServiceGraphUpdate) (ServiceGraph, error) {
+	if id == "" {
+		return ServiceGraph{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(serviceGraphExtension); err != nil {
+		return ServiceGraph{}, err
+	}
+
+	var r SingleServiceGraph
+	body := map[string]ServiceGraphUpdate{"service_graph": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/sfc/service_graphs/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return ServiceGraph{}, err
+	}
+	return r.ServiceGraph, nil
+}
+
+func (c *Client) DeleteServiceGraph(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(serviceGraphExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/sfc/service_graphs/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+// ServiceFunction is one hop of a chain built by CreatePortChainFromPorts:
+// traffic enters the service function through Ingress and leaves it through
+// Egress.
+type ServiceFunction struct {
+	Ingress Port
+	Egress  Port
+}
+
+// PortChainSpec describes a chain of service functions, in the order the
+// traffic traverses them.
+type PortChainSpec struct {
+	Name              string
+	Hops              []ServiceFunction
+	FlowClassifierIDs []string
+	ChainParameters   *PortChainParameters
+}
+
+// ServiceChain holds the resources created by CreatePortChainFromPorts.
+// PortPairs and PortPairGroups are in hop order.
+type ServiceChain struct {
+	PortPairs      []PortPair
+	PortPairGroups []PortPairGroup
+	PortChain      PortChain
+}
+
+// CreatePortChainFromPorts creates a port pair and a port pair group for each
+// hop of the spec, then the port chain through the groups. If a step fails,
+// the resources already created are deleted.
+func (c *Client) CreatePortChainFromPorts(spec PortChainSpec) (ServiceChain, error) {
+	if len(spec.Hops) == 0 {
+		return ServiceChain{}, fmt.Errorf("missing service function hops")
+	}
+	for i, h := range spec.Hops {
+		if h.Ingress.ID == "" || h.Egress.ID == "" {
+			return ServiceChain{}, fmt.Errorf("hop %d: missing ingress or egress port id", i)
+		}
+	}
+	if err := c.requireExtension(sfcExtension); err != nil {
+		return ServiceChain{}, err
+	}
+
+	var (
+		sc       ServiceChain
+		rollback rollback
+	)
+	fail := func(err error) (ServiceChain, error) {
+		return ServiceChain{}, rollback.run(err)
+	}
+	hopName := func(i int) string {
+		if spec.Name == "" {
+			return ""
+		}
+		return fmt.Sprintf("%s-%d", spec.Name, i)
+	}
+
+	for i, h := range spec.Hops {
+		pp, err := c.CreatePortPair(PortPair{
+			Name:    hopName(i),
+			Ingress: h.Ingress.ID,
+			Egress:  h.Egress.ID,
+		})
+		if err != nil {
+			return fail(fmt.Errorf("hop %d: %v", i, err))
+		}
+		sc.PortPairs = append(sc.PortPairs, pp)
+		rollback = append(rollback, func() error { return c.DeletePortPair(pp.ID) })
+
+		g, err := c.CreatePortPairGroup(PortPairGroup{
+			Name:      hopName(i),
+			PortPairs: []string{pp.ID},
+		})
+		if err != nil {
+			return fail(fmt.Errorf("hop %d: %v", i, err))
+		}
+		sc.PortPairGroups = append(sc.PortPairGroups, g)
+		rollback = append(rollback, func() error { return c.DeletePortPairGroup(g.ID) })
+	}
+
+	groups := make([]string, len(sc.PortPairGroups))
+	for i, g := range sc.PortPairGroups {
+		groups[i] = g.ID
+	}
+	pc, err := c.CreatePortChain(PortChain{
+		Name:            spec.Name,
+		PortPairGroups:  groups,
+		FlowClassifiers: spec.FlowClassifierIDs,
+		ChainParameters: spec.ChainParameters,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	sc.PortChain = pc
+	return sc, nil
+}
+
+// DeleteServiceChain deletes the port chain, then the port pair groups and
+// port pairs of a chain created by CreatePortChainFromPorts.
+func (c *Client) DeleteServiceChain(sc ServiceChain) error {
+	if sc.PortChain.ID != "" {
+		if err := c.DeletePortChain(sc.PortChain.ID); err != nil {
+			return err
+		}
+	}
+	for _, g := range sc.PortPairGroups {
+		if err := c.DeletePortPairGroup(g.ID); err != nil {
+			return err
+		}
+	}
+	for _, pp := range sc.PortPairs {
+		if err := c.DeletePortPair(pp.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
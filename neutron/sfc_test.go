@@ -0,0 +1,398 @@
+package neutron_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const portChainsResp = `{
+  "port_chains": [
+    {
+      "id": "1278dcd4-459f-62ed-754b-87fc5e4a6751",
+      "name": "PC2",
+      "description": "Steering TCP and UDP traffic first to HTTP Firewall and then to Firewall",
+      "port_pair_groups": [
+        "4512d643-24fc-4fae-af4b-321c5e2eb3d1",
+        "4a634d49-76dc-4fae-af4b-321c5e23d651"
+      ],
+      "flow_classifiers": [
+        "4a334cd4-fe9c-4fae-af4b-321c5e2eb051"
+      ],
+      "chain_parameters": {
+        "correlation": "mpls",
+        "symmetric": false
+      },
+      "chain_id": 2
+    }
+  ]
+}`
+
+const flowClassifiersResp = `{
+  "flow_classifiers": [
+    {
+      "id": "4a334cd4-fe9c-4fae-af4b-321c5e2eb051",
+      "name": "FC1",
+      "ethertype": "IPv4",
+      "protocol": "tcp",
+      "source_port_range_min": 22,
+      "source_port_range_max": 4000,
+      "destination_port_range_min": 80,
+      "destination_port_range_max": 80,
+      "source_ip_prefix": "22.12.34.44",
+      "destination_ip_prefix": "22.12.34.45",
+      "logical_source_port": "dace4513-24fc-4fae-af4b-321c5e2eb3d1",
+      "l7_parameters": {}
+    }
+  ]
+}`
+
+const flowClassifierResp = `{
+  "flow_classifier": {
+    "id": "4a334cd4-fe9c-4fae-af4b-321c5e2eb051",
+    "name": "FC1",
+    "ethertype": "IPv6",
+    "protocol": "udp",
+    "logical_destination_port": "aef3478a-4a56-2a6e-cd3a-9dee4e2ec345"
+  }
+}`
+
+const portPairGroupsResp = `{
+  "port_pair_groups": [
+    {
+      "id": "4512d643-24fc-4fae-af4b-321c5e2eb3d1",
+      "name": "Firewall_PortPairGroup",
+      "port_pairs": [
+        "78dcd363-fc23-aeb6-f44b-56dc5e2fb3ae",
+        "d11e9190-73d4-11e5-b392-2c27d72acb4c"
+      ],
+      "port_pair_group_parameters": {"lb_fields": "ip_src&ip_dst"},
+      "tap_enabled": false
+    }
+  ]
+}`
+
+const portPairGroupResp = `{
+  "port_pair_group": {
+    "id": "4512d643-24fc-4fae-af4b-321c5e2eb3d1",
+    "name": "Tap_PortPairGroup",
+    "port_pairs": ["78dcd363-fc23-aeb6-f44b-56dc5e2fb3ae"],
+    "tap_enabled": true
+  }
+}`
+
+const serviceGraphsResp = `{
+  "service_graphs": [
+    {
+      "id": "2bb9ab0e-8ba8-4a2b-9ac0-f4fe9fd2ad38",
+      "name": "graph1",
+      "port_chains": {
+        "1278dcd4-459f-62ed-754b-87fc5e4a6751": [
+          "b8f1e0a5-0c8b-4b5c-8a0e-0a4d2c6bb4f5",
+          "c63b2c6e-31e2-4ad4-a0ad-9d3ab5d0c8c1"
+        ]
+      }
+    }
+  ]
+}`
+
+const serviceGraphResp = `{
+  "service_graph": {
+    "id": "2bb9ab0e-8ba8-4a2b-9ac0-f4fe9fd2ad38",
+    "name": "graph1",
+    "description": "branch to the IDS chain",
+    "port_chains": {
+      "1278dcd4-459f-62ed-754b-87fc5e4a6751": ["c63b2c6e-31e2-4ad4-a0ad-9d3ab5d0c8c1"]
+    }
+  }
+}`
+
+var sfcGetResps = map[string]string{
+	"/v2.0/sfc/port_chains":           portChainsResp,
+	"/v2.0/sfc/flow_classifiers":      flowClassifiersResp,
+	"/v2.0/sfc/flow_classifiers/fc1":  flowClassifierResp,
+	"/v2.0/sfc/port_pair_groups":      portPairGroupsResp,
+	"/v2.0/sfc/port_pair_groups/ppg1": portPairGroupResp,
+	"/v2.0/sfc/service_graphs":        serviceGraphsResp,
+	"/v2.0/sfc/service_graphs/graph1": serviceGraphResp,
+}
+
+var _ = Describe("SFC", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		requests []string
+		bodies   map[string][]byte
+		failOn   string
+		created  int
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		bodies = map[string][]byte{}
+		failOn = ""
+		created = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies[r.Method+" "+r.URL.Path] = body
+
+			if r.Method+" "+r.URL.Path == failOn {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			switch r.Method {
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodPost:
+				// echo the resource back with a sequential id
+				var in map[string]map[string]interface{}
+				json.Unmarshal(body, &in)
+				for key, res := range in {
+					created++
+					res["id"] = fmt.Sprintf("%s-%d", key, created)
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(map[string]interface{}{key: res})
+				}
+			case http.MethodPut:
+				w.Write(body)
+			default:
+				fmt.Fprintln(w, sfcGetResps[r.URL.Path])
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists port chains", func() {
+		chains, err := client.PortChains()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal([]string{"GET /v2.0/sfc/port_chains"}))
+		Expect(chains).To(HaveLen(1))
+		Expect(chains[0].PortPairGroups).To(HaveLen(2))
+		Expect(chains[0].ChainParameters.Correlation).To(Equal("mpls"))
+		Expect(chains[0].ChainID).To(Equal(2))
+	})
+
+	It("creates a flow classifier", func() {
+		fc, err := client.CreateFlowClassifier(neutron.FlowClassifier{
+			Name:                    "web",
+			EtherType:               "IPv4",
+			Protocol:                "tcp",
+			DestinationPortRangeMin: 80,
+			DestinationPortRangeMax: 80,
+			LogicalSourcePort:       "port1",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fc.ID).To(Equal("flow_classifier-1"))
+		Expect(bodies["POST /v2.0/sfc/flow_classifiers"]).To(MatchJSON(`{
+			"flow_classifier": {
+				"name": "web",
+				"ethertype": "IPv4",
+				"protocol": "tcp",
+				"destination_port_range_min": 80,
+				"destination_port_range_max": 80,
+				"logical_source_port": "port1"
+			}
+		}`))
+	})
+
+	Describe("flow classifiers", func() {
+		It("lists flow classifiers", func() {
+			fcs, err := client.FlowClassifiers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/sfc/flow_classifiers"}))
+			Expect(fcs).To(HaveLen(1))
+			Expect(fcs[0].SourcePortRangeMin).To(Equal(22))
+			Expect(fcs[0].SourcePortRangeMax).To(Equal(4000))
+			Expect(fcs[0].DestinationIPPrefix).To(Equal("22.12.34.45"))
+			Expect(fcs[0].LogicalSourcePort).To(Equal("dace4513-24fc-4fae-af4b-321c5e2eb3d1"))
+		})
+
+		It("gets a flow classifier", func() {
+			fc, err := client.FlowClassifier("fc1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/sfc/flow_classifiers/fc1"}))
+			Expect(fc.EtherType).To(Equal("IPv6"))
+			Expect(fc.LogicalDestinationPort).To(Equal("aef3478a-4a56-2a6e-cd3a-9dee4e2ec345"))
+		})
+
+		It("updates a flow classifier", func() {
+			name := "FC2"
+			fc, err := client.UpdateFlowClassifier("fc1", neutron.FlowClassifierUpdate{Name: &name})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"PUT /v2.0/sfc/flow_classifiers/fc1"}))
+			Expect(bodies["PUT /v2.0/sfc/flow_classifiers/fc1"]).To(MatchJSON(`{"flow_classifier": {"name": "FC2"}}`))
+			Expect(fc.Name).To(Equal("FC2"))
+		})
+
+		It("deletes a flow classifier", func() {
+			err := client.DeleteFlowClassifier("fc1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"DELETE /v2.0/sfc/flow_classifiers/fc1"}))
+		})
+
+		It("requires an id", func() {
+			_, err := client.FlowClassifier("")
+			Expect(err).To(MatchError("empty 'id' parameter"))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	Describe("port pair groups", func() {
+		It("lists port pair groups", func() {
+			groups, err := client.PortPairGroups()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/sfc/port_pair_groups"}))
+			Expect(groups).To(HaveLen(1))
+			Expect(groups[0].PortPairs).To(HaveLen(2))
+			Expect(groups[0].PortPairGroupParameters).To(HaveKeyWithValue("lb_fields", "ip_src&ip_dst"))
+		})
+
+		It("gets a port pair group", func() {
+			g, err := client.PortPairGroup("ppg1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/sfc/port_pair_groups/ppg1"}))
+			Expect(g.TapEnabled).To(BeTrue())
+		})
+
+		It("creates a port pair group", func() {
+			g, err := client.CreatePortPairGroup(neutron.PortPairGroup{Name: "fw", PortPairs: []string{"pp1", "pp2"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bodies["POST /v2.0/sfc/port_pair_groups"]).To(MatchJSON(`{
+				"port_pair_group": {"name": "fw", "port_pairs": ["pp1", "pp2"]}
+			}`))
+			Expect(g.ID).To(Equal("port_pair_group-1"))
+		})
+
+		It("replaces the port pairs of a group", func() {
+			pairs := []string{"pp3"}
+			g, err := client.UpdatePortPairGroup("ppg1", neutron.PortPairGroupUpdate{PortPairs: &pairs})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"PUT /v2.0/sfc/port_pair_groups/ppg1"}))
+			Expect(bodies["PUT /v2.0/sfc/port_pair_groups/ppg1"]).To(MatchJSON(`{"port_pair_group": {"port_pairs": ["pp3"]}}`))
+			Expect(g.PortPairs).To(Equal([]string{"pp3"}))
+		})
+
+		It("deletes a port pair group", func() {
+			err := client.DeletePortPairGroup("ppg1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"DELETE /v2.0/sfc/port_pair_groups/ppg1"}))
+		})
+	})
+
+	Describe("service graphs", func() {
+		It("lists service graphs", func() {
+			graphs, err := client.ServiceGraphs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/sfc/service_graphs"}))
+			Expect(graphs).To(HaveLen(1))
+			Expect(graphs[0].PortChains).To(HaveKeyWithValue("1278dcd4-459f-62ed-754b-87fc5e4a6751", []string{
+				"b8f1e0a5-0c8b-4b5c-8a0e-0a4d2c6bb4f5",
+				"c63b2c6e-31e2-4ad4-a0ad-9d3ab5d0c8c1",
+			}))
+		})
+
+		It("gets a service graph", func() {
+			g, err := client.ServiceGraph("graph1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/sfc/service_graphs/graph1"}))
+			Expect(g.Description).To(Equal("branch to the IDS chain"))
+		})
+
+		It("creates a service graph", func() {
+			g, err := client.CreateServiceGraph(neutron.ServiceGraph{
+				Name:       "graph1",
+				PortChains: map[string][]string{"pc1": {"pc2", "pc3"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bodies["POST /v2.0/sfc/service_graphs"]).To(MatchJSON(`{
+				"service_graph": {"name": "graph1", "port_chains": {"pc1": ["pc2", "pc3"]}}
+			}`))
+			Expect(g.ID).To(Equal("service_graph-1"))
+			Expect(g.PortChains["pc1"]).To(Equal([]string{"pc2", "pc3"}))
+		})
+
+		It("updates a service graph", func() {
+			description := "updated"
+			_, err := client.UpdateServiceGraph("graph1", neutron.ServiceGraphUpdate{Description: &description})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"PUT /v2.0/sfc/service_graphs/graph1"}))
+			Expect(bodies["PUT /v2.0/sfc/service_graphs/graph1"]).To(MatchJSON(`{"service_graph": {"description": "updated"}}`))
+		})
+
+		It("deletes a service graph", func() {
+			err := client.DeleteServiceGraph("graph1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"DELETE /v2.0/sfc/service_graphs/graph1"}))
+		})
+	})
+
+	Describe("CreatePortChainFromPorts", func() {
+		spec := neutron.PortChainSpec{
+			Name: "nfv",
+			Hops: []neutron.ServiceFunction{
+				{Ingress: neutron.Port{ID: "fw-in"}, Egress: neutron.Port{ID: "fw-out"}},
+				{Ingress: neutron.Port{ID: "dpi"}, Egress: neutron.Port{ID: "dpi"}},
+			},
+			FlowClassifierIDs: []string{"fc1"},
+		}
+
+		It("creates pairs and groups in hop order, then the chain", func() {
+			sc, err := client.CreatePortChainFromPorts(spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{
+				"POST /v2.0/sfc/port_pairs",
+				"POST /v2.0/sfc/port_pair_groups",
+				"POST /v2.0/sfc/port_pairs",
+				"POST /v2.0/sfc/port_pair_groups",
+				"POST /v2.0/sfc/port_chains",
+			}))
+			Expect(sc.PortPairs[1].Ingress).To(Equal("dpi"))
+			Expect(sc.PortPairGroups[1].PortPairs).To(Equal([]string{"port_pair-3"}))
+			Expect(bodies["POST /v2.0/sfc/port_chains"]).To(MatchJSON(`{
+				"port_chain": {
+					"name": "nfv",
+					"port_pair_groups": ["port_pair_group-2", "port_pair_group-4"],
+					"flow_classifiers": ["fc1"]
+				}
+			}`))
+		})
+
+		It("deletes the created resources when a step fails", func() {
+			failOn = "POST /v2.0/sfc/port_chains"
+			_, err := client.CreatePortChainFromPorts(spec)
+			Expect(err).To(HaveOccurred())
+			Expect(requests[5:]).To(Equal([]string{
+				"DELETE /v2.0/sfc/port_pair_groups/port_pair_group-4",
+				"DELETE /v2.0/sfc/port_pairs/port_pair-3",
+				"DELETE /v2.0/sfc/port_pair_groups/port_pair_group-2",
+				"DELETE /v2.0/sfc/port_pairs/port_pair-1",
+			}))
+		})
+
+		It("requires port ids for every hop", func() {
+			_, err := client.CreatePortChainFromPorts(neutron.PortChainSpec{
+				Hops: []neutron.ServiceFunction{{Ingress: neutron.Port{ID: "a"}}},
+			})
+			Expect(err).To(MatchError("hop 0: missing ingress or egress port id"))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})
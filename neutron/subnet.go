@@ -1,5 +1,7 @@
 package neutron
 
+import "time"
+
 type Subnet struct {
 	ID              string           `json:"id,omitempty"`
 	Name            string           `json:"name,omitempty"`
@@ -15,6 +17,28 @@ type Subnet struct {
 	IPVersion       int              `json:"ip_version"`
 	GatewayIP       string           `json:"gateway_ip,omitempty"`
 	CIDR            string           `json:"cidr"`
+	Tags            []string         `json:"tags,omitempty"`
+
+	// RevisionNumber, CreatedAt and UpdatedAt are read only; they are never
+	// sent to Neutron.
+	RevisionNumber int       `json:"-"`
+	CreatedAt      time.Time `json:"-"`
+	UpdatedAt      time.Time `json:"-"`
+
+	Extras Extras `json:"-"`
+}
+
+// SubnetUpdate holds the subnet attributes to change; nil fields are left as
+// is.
+type SubnetUpdate struct {
+	Name            *string           `json:"name,omitempty"`
+	EnableDHCP      *bool             `json:"enable_dhcp,omitempty"`
+	DNSNameservers  *[]string         `json:"dns_nameservers,omitempty"`
+	AllocationPools *[]AllocationPool `json:"allocation_pools,omitempty"`
+	HostRoutes      *[]string         `json:"host_routes,omitempty"`
+	GatewayIP       *string           `json:"gateway_ip,omitempty"`
+
+	Extras Extras `json:"-"`
 }
 
 type AllocationPool struct {
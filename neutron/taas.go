@@ -0,0 +1,317 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type TapDirection string
+
+const (
+	TapDirectionIn   TapDirection = "IN"
+	TapDirectionOut  TapDirection = "OUT"
+	TapDirectionBoth TapDirection = "BOTH"
+)
+
+// TapService is the destination of mirrored traffic, such as the port of an
+// IDS instance.
+type TapService struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	PortID      string `json:"port_id,omitempty"`
+	Status      string `json:"status,omitempty"`
+	TenantID    string `json:"tenant_id,omitempty"`
+	ProjectID   string `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetTapServices struct {
+	TapServices []TapService `json:"tap_services"`
+}
+
+type SingleTapService struct {
+	TapService TapService `json:"tap_service"`
+}
+
+// TapServiceUpdate holds the tap service attributes to change; nil fields are
+// left as is.
+type TapServiceUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// TapFlow mirrors the traffic of SourcePort to a tap service. VLANFilter,
+// e.g. "9,18-27", limits mirroring to the given VLAN IDs.
+type TapFlow struct {
+	ID           string       `json:"id,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	Description  string       `json:"description,omitempty"`
+	TapServiceID string       `json:"tap_service_id,omitempty"`
+	SourcePort   string       `json:"source_port,omitempty"`
+	Direction    TapDirection `json:"direction,omitempty"`
+	VLANFilter   string       `json:"vlan_filter,omitempty"`
+	Status       string       `json:"status,omitempty"`
+	TenantID     string       `json:"tenant_id,omitempty"`
+	ProjectID    string       `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetTapFlows struct {
+	TapFlows []TapFlow `json:"tap_flows"`
+}
+
+type SingleTapFlow struct {
+	TapFlow TapFlow `json:"tap_flow"`
+}
+
+// TapFlowUpdate holds the tap flow attributes to change; nil fields are left
+// as is.
+type TapFlowUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+const (
+	taasExtension           = "taas"
+	taasVLANFilterExtension = "taas-vlan-filter"
+)
+
+// ValidateVLANFilter checks a comma separated list of VLAN IDs and ranges,
+// such as "9,18-27".
+func ValidateVLANFilter(filter string) error {
+	for _, part := range strings.Split(filter, ",") {
+		bounds := strings.SplitN(part, "-", 2)
+		if len(bounds) == 1 {
+			bounds = append(bounds, bounds[0])
+		}
+		first, err1 := strconv.Atoi(bounds[0])
+		last, err2 := strconv.Atoi(bounds[1])
+		if err1 != nil || err2 != nil || first < 0 || last > 4095 || first > last {
+			return fmt.Errorf("invalid VLAN filter '%s'", filter)
+		}
+	}
+	return nil
+}
+
+func validateTapFlow(f TapFlow) error {
+	if f.TapServiceID == "" {
+		return fmt.Errorf("missing tap service id")
+	}
+	if f.SourcePort == "" {
+		return fmt.Errorf("missing source port")
+	}
+	switch f.Direction {
+	case TapDirectionIn, TapDirectionOut, TapDirectionBoth:
+	default:
+		return fmt.Errorf("invalid tap flow direction '%s'", f.Direction)
+	}
+	if f.VLANFilter != "" {
+		return ValidateVLANFilter(f.VLANFilter)
+	}
+	return nil
+}
+
+func (c *Client) TapServices(opts ...ListOpts) ([]TapService, error) {
+	if err := c.requireExtension(taasExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetTapServices
+	err := c.send(http.MethodGet, c.listURL("taas/tap_services", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.TapServices, nil
+}
+
+func (c *Client) TapService(id string) (TapService, error) {
+	if id == "" {
+		return TapService{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(taasExtension); err != nil {
+		return TapService{}, err
+	}
+
+	var r SingleTapService
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/taas/tap_services/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return TapService{}, err
+	}
+	return r.TapService, nil
+}
+
+func (c *Client) CreateTapService(s TapService) (TapService, error) {
+	if s.PortID == "" {
+		return TapService{}, fmt.Errorf("missing port id")
+	}
+	if err := c.requireExtension(taasExtension); err != nil {
+		return TapService{}, err
+	}
+
+	var r SingleTapService
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/taas/tap_services", c.URL), SingleTapService{TapService: s}, http.StatusCreated, &r)
+	if err != nil {
+		return TapService{}, err
+	}
+	return r.TapService, nil
+}
+
+func (c *Client) UpdateTapService(id string, u TapServiceUpdate) (TapService, error) {
+	if id == "" {
+		return TapService{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(taasExtension); err != nil {
+		return TapService{}, err
+	}
+
+	var r SingleTapService
+	body := map[string]TapServiceUpdate{"tap_service": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/taas/tap_services/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return TapService{}, err
+	}
+	return r.TapService, nil
+}
+
+func (c *Client) DeleteTapService(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(taasExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/taas/tap_services/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) TapFlows(opts ...ListOpts) ([]TapFlow, error) {
+	if err := c.requireExtension(taasExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetTapFlows
+	err := c.send(http.MethodGet, c.listURL("taas/tap_flows", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.TapFlows, nil
+}
+
+func (c *Client) TapFlow(id string) (TapFlow, error) {
+	if id == "" {
+		return TapFlow{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(taasExtension); err != nil {
+		return TapFlow{}, err
+	}
+
+	var r SingleTapFlow
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/taas/tap_flows/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return TapFlow{}, err
+	}
+	return r.TapFlow, nil
+}
+
+func (c *Client) CreateTapFlow(f TapFlow) (TapFlow, error) {
+	if err := validateTapFlow(f); err != nil {
+		return TapFlow{}, err
+	}
+	if err := c.requireExtension(taasExtension); err != nil {
+		return TapFlow{}, err
+	}
+	if f.VLANFilter != "" {
+		if err := c.requireExtension(taasVLANFilterExtension); err != nil {
+			return TapFlow{}, err
+		}
+	}
+
+	var r SingleTapFlow
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/taas/tap_flows", c.URL), SingleTapFlow{TapFlow: f}, http.StatusCreated, &r)
+	if err != nil {
+		return TapFlow{}, err
+	}
+	return r.TapFlow, nil
+}
+
+func (c *Client) UpdateTapFlow(id string, u TapFlowUpdate) (TapFlow, error) {
+	if id == "" {
+		return TapFlow{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(taasExtension); err != nil {
+		return TapFlow{}, err
+	}
+
+	var r SingleTapFlow
+	body := map[string]TapFlowUpdate{"tap_flow": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/taas/tap_flows/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return TapFlow{}, err
+	}
+	return r.TapFlow, nil
+}
+
+func (c *Client) DeleteTapFlow(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(taasExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/taas/tap_flows/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+// MirrorNetwork creates a tap flow to the tap service for every port of the
+// network, except the destination port of the service itself and the ports
+// Neutron owns, such as router interfaces and DHCP ports, whose device owner
+// starts with "network:". If a flow cannot be created, the flows already
+// created are deleted.
+func (c *Client) MirrorNetwork(networkID, tapServiceID string, direction TapDirection) ([]TapFlow, error) {
+	if networkID == "" {
+		return nil, fmt.Errorf("empty 'networkID' parameter")
+	}
+	if tapServiceID == "" {
+		return nil, fmt.Errorf("empty 'tapServiceID' parameter")
+	}
+
+	service, err := c.TapService(tapServiceID)
+	if err != nil {
+		return nil, err
+	}
+	ports, err := c.Ports(ListOpts{Filters: map[string]string{"network_id": networkID}})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		flows    []TapFlow
+		rollback rollback
+	)
+	for _, p := range ports {
+		if p.ID == service.PortID || strings.HasPrefix(p.DeviceOwner, "network:") {
+			continue
+		}
+		f, err := c.CreateTapFlow(TapFlow{
+			Name:         p.Name,
+			TapServiceID: tapServiceID,
+			SourcePort:   p.ID,
+			Direction:    direction,
+		})
+		if err != nil {
+			return nil, rollback.run(fmt.Errorf("port '%s': %v", p.ID, err))
+		}
+		flows = append(flows, f)
+		rollback = append(rollback, func() error { return c.DeleteTapFlow(f.ID) })
+	}
+	return flows, nil
+}
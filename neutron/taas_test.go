@@ -0,0 +1,268 @@
+package neutron_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const tapServiceResp = `{
+  "tap_service": {
+    "id": "c352f537-ad49-48eb-ab05-1c6b8cb900ff",
+    "name": "ids",
+    "port_id": "ids-port",
+    "status": "ACTIVE"
+  }
+}`
+
+const tapServicesResp = `{
+  "tap_services": [
+    {"id": "c352f537-ad49-48eb-ab05-1c6b8cb900ff", "name": "ids", "port_id": "ids-port", "status": "ACTIVE"},
+    {"id": "7e2a9e4f-6c1d-4b8a-9f0e-2d5c8b1a3f47", "name": "capture", "port_id": "pcap-port", "status": "PENDING_CREATE"}
+  ]
+}`
+
+const tapFlowsResp = `{
+  "tap_flows": [
+    {
+      "id": "flow-1",
+      "tap_service_id": "c352f537-ad49-48eb-ab05-1c6b8cb900ff",
+      "source_port": "web-port",
+      "direction": "IN",
+      "vlan_filter": "9,18-27",
+      "status": "ACTIVE"
+    }
+  ]
+}`
+
+const tapFlowResp = `{
+  "tap_flow": {
+    "id": "flow-1",
+    "name": "web",
+    "tap_service_id": "c352f537-ad49-48eb-ab05-1c6b8cb900ff",
+    "source_port": "web-port",
+    "direction": "OUT",
+    "status": "ACTIVE"
+  }
+}`
+
+const tapNetworkPortsResp = `{
+  "ports": [
+    {"id": "web-port", "name": "web", "network_id": "net1"},
+    {"id": "ids-port", "name": "ids", "network_id": "net1"},
+    {"id": "dhcp-port", "network_id": "net1", "device_owner": "network:dhcp"},
+    {"id": "gw-port", "network_id": "net1", "device_owner": "network:router_interface"},
+    {"id": "db-port", "name": "db", "network_id": "net1", "device_owner": "compute:nova"}
+  ]
+}`
+
+var _ = Describe("Tap as a Service", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		requests []string
+		bodies   []string
+		failOn   string
+		created  int
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		bodies = nil
+		failOn = ""
+		created = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests = append(requests, r.Method+" "+r.URL.RequestURI())
+			body, _ := ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPut:
+				bodies = append(bodies, string(body))
+				w.Write(body)
+			case r.Method == http.MethodPost && r.URL.Path == "/v2.0/taas/tap_services":
+				bodies = append(bodies, string(body))
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, tapServiceResp)
+			case r.Method == http.MethodPost:
+				bodies = append(bodies, string(body))
+				var in neutron.SingleTapFlow
+				json.Unmarshal(body, &in)
+				if in.TapFlow.SourcePort == failOn {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				created++
+				in.TapFlow.ID = fmt.Sprintf("flow-%d", created)
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(in)
+			case r.URL.Path == "/v2.0/ports":
+				fmt.Fprintln(w, tapNetworkPortsResp)
+			case r.URL.Path == "/v2.0/taas/tap_services":
+				fmt.Fprintln(w, tapServicesResp)
+			case r.URL.Path == "/v2.0/taas/tap_flows":
+				fmt.Fprintln(w, tapFlowsResp)
+			case r.URL.Path == "/v2.0/taas/tap_flows/flow-1":
+				fmt.Fprintln(w, tapFlowResp)
+			default:
+				fmt.Fprintln(w, tapServiceResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("creates a tap flow with a VLAN filter", func() {
+		_, err := client.CreateTapFlow(neutron.TapFlow{
+			TapServiceID: "c352f537-ad49-48eb-ab05-1c6b8cb900ff",
+			SourcePort:   "web-port",
+			Direction:    neutron.TapDirectionBoth,
+			VLANFilter:   "9,18-27",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal([]string{"POST /v2.0/taas/tap_flows"}))
+		Expect(bodies[0]).To(MatchJSON(`{
+			"tap_flow": {
+				"tap_service_id": "c352f537-ad49-48eb-ab05-1c6b8cb900ff",
+				"source_port": "web-port",
+				"direction": "BOTH",
+				"vlan_filter": "9,18-27"
+			}
+		}`))
+	})
+
+	It("rejects invalid tap flows", func() {
+		_, err := client.CreateTapFlow(neutron.TapFlow{TapServiceID: "ts", SourcePort: "p", Direction: "UP"})
+		Expect(err).To(MatchError("invalid tap flow direction 'UP'"))
+
+		_, err = client.CreateTapFlow(neutron.TapFlow{TapServiceID: "ts", SourcePort: "p", Direction: neutron.TapDirectionIn, VLANFilter: "27-18"})
+		Expect(err).To(MatchError("invalid VLAN filter '27-18'"))
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("mirrors every port of a network except the tap service and network ports", func() {
+		flows, err := client.MirrorNetwork("net1", "c352f537-ad49-48eb-ab05-1c6b8cb900ff", neutron.TapDirectionIn)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal([]string{
+			"GET /v2.0/taas/tap_services/c352f537-ad49-48eb-ab05-1c6b8cb900ff",
+			"GET /v2.0/ports?network_id=net1",
+			"POST /v2.0/taas/tap_flows",
+			"POST /v2.0/taas/tap_flows",
+		}))
+		Expect(flows).To(HaveLen(2))
+		Expect(flows[0].SourcePort).To(Equal("web-port"))
+		Expect(flows[1].SourcePort).To(Equal("db-port"))
+	})
+
+	It("deletes the created flows when mirroring fails", func() {
+		failOn = "db-port"
+		_, err := client.MirrorNetwork("net1", "c352f537-ad49-48eb-ab05-1c6b8cb900ff", neutron.TapDirectionIn)
+		Expect(err).To(HaveOccurred())
+		Expect(requests[len(requests)-1]).To(Equal("DELETE /v2.0/taas/tap_flows/flow-1"))
+	})
+
+	Describe("tap services", func() {
+		It("lists tap services", func() {
+			services, err := client.TapServices()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/taas/tap_services"}))
+			Expect(services).To(HaveLen(2))
+			Expect(services[1].PortID).To(Equal("pcap-port"))
+			Expect(services[1].Status).To(Equal("PENDING_CREATE"))
+		})
+
+		It("gets a tap service", func() {
+			s, err := client.TapService("c352f537-ad49-48eb-ab05-1c6b8cb900ff")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/taas/tap_services/c352f537-ad49-48eb-ab05-1c6b8cb900ff"}))
+			Expect(s.Name).To(Equal("ids"))
+			Expect(s.PortID).To(Equal("ids-port"))
+		})
+
+		It("creates a tap service", func() {
+			s, err := client.CreateTapService(neutron.TapService{Name: "ids", PortID: "ids-port"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"POST /v2.0/taas/tap_services"}))
+			Expect(bodies[0]).To(MatchJSON(`{"tap_service": {"name": "ids", "port_id": "ids-port"}}`))
+			Expect(s.ID).To(Equal("c352f537-ad49-48eb-ab05-1c6b8cb900ff"))
+		})
+
+		It("requires the port of a tap service", func() {
+			_, err := client.CreateTapService(neutron.TapService{Name: "ids"})
+			Expect(err).To(MatchError("missing port id"))
+			Expect(requests).To(BeEmpty())
+		})
+
+		It("updates a tap service", func() {
+			description := "suricata"
+			_, err := client.UpdateTapService("c352f537-ad49-48eb-ab05-1c6b8cb900ff", neutron.TapServiceUpdate{Description: &description})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"PUT /v2.0/taas/tap_services/c352f537-ad49-48eb-ab05-1c6b8cb900ff"}))
+			Expect(bodies[0]).To(MatchJSON(`{"tap_service": {"description": "suricata"}}`))
+		})
+
+		It("deletes a tap service", func() {
+			err := client.DeleteTapService("c352f537-ad49-48eb-ab05-1c6b8cb900ff")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"DELETE /v2.0/taas/tap_services/c352f537-ad49-48eb-ab05-1c6b8cb900ff"}))
+		})
+	})
+
+	Describe("tap flows", func() {
+		It("lists tap flows", func() {
+			flows, err := client.TapFlows(neutron.ListOpts{Filters: map[string]string{"tap_service_id": "c352f537-ad49-48eb-ab05-1c6b8cb900ff"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/taas/tap_flows?tap_service_id=c352f537-ad49-48eb-ab05-1c6b8cb900ff"}))
+			Expect(flows).To(HaveLen(1))
+			Expect(flows[0].Direction).To(Equal(neutron.TapDirectionIn))
+			Expect(flows[0].VLANFilter).To(Equal("9,18-27"))
+		})
+
+		It("gets a tap flow", func() {
+			f, err := client.TapFlow("flow-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"GET /v2.0/taas/tap_flows/flow-1"}))
+			Expect(f.Name).To(Equal("web"))
+			Expect(f.Direction).To(Equal(neutron.TapDirectionOut))
+		})
+
+		It("updates a tap flow", func() {
+			name := "web-mirror"
+			f, err := client.UpdateTapFlow("flow-1", neutron.TapFlowUpdate{Name: &name})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"PUT /v2.0/taas/tap_flows/flow-1"}))
+			Expect(bodies[0]).To(MatchJSON(`{"tap_flow": {"name": "web-mirror"}}`))
+			Expect(f.Name).To(Equal("web-mirror"))
+		})
+
+		It("deletes a tap flow", func() {
+			err := client.DeleteTapFlow("flow-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{"DELETE /v2.0/taas/tap_flows/flow-1"}))
+		})
+
+		It("requires an id", func() {
+			_, err := client.TapFlow("")
+			Expect(err).To(MatchError("empty 'id' parameter"))
+			err = client.DeleteTapFlow("")
+			Expect(err).To(MatchError("empty 'id' parameter"))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,147 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Collection names of resources that support tags.
+const (
+	NetworksResource       = "networks"
+	SubnetsResource        = "subnets"
+	PortsResource          = "ports"
+	RoutersResource        = "routers"
+	SubnetPoolsResource    = "subnetpools"
+	FloatingIPsResource    = "floatingips"
+	SecurityGroupsResource = "security-groups"
+	QoSPoliciesResource    = "policies"
+	TrunksResource         = "trunks"
+)
+
+type Tags struct {
+	Tags []string `json:"tags"`
+}
+
+func (c *Client) tagsURL(resource, id string) string {
+	return fmt.Sprintf("%s/v2.0/%s/%s/tags", c.URL, resource, id)
+}
+
+func (c *Client) tagURL(resource, id, tag string) string {
+	return fmt.Sprintf("%s/%s", c.tagsURL(resource, id), url.PathEscape(tag))
+}
+
+var tagExtensions = []string{"standard-attr-tag", "tag-ext", "tag"}
+
+func checkTagParams(resource, id string) error {
+	if resource == "" {
+		return fmt.Errorf("empty 'resource' parameter")
+	}
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	return nil
+}
+
+func (c *Client) Tags(resource, id string) ([]string, error) {
+	if err := checkTagParams(resource, id); err != nil {
+		return nil, err
+	}
+
+	if err := c.requireExtension(tagExtensions...); err != nil {
+		return nil, err
+	}
+
+	var r Tags
+	err := c.send(http.MethodGet, c.tagsURL(resource, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Tags, nil
+}
+
+func (c *Client) HasTag(resource, id, tag string) (bool, error) {
+	if err := checkTagParams(resource, id); err != nil {
+		return false, err
+	}
+	if tag == "" {
+		return false, fmt.Errorf("empty 'tag' parameter")
+	}
+
+	if err := c.requireExtension(tagExtensions...); err != nil {
+		return false, err
+	}
+
+	err := c.send(http.MethodGet, c.tagURL(resource, id, tag), nil, http.StatusNoContent, nil)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Client) AddTag(resource, id, tag string) error {
+	if err := checkTagParams(resource, id); err != nil {
+		return err
+	}
+	if tag == "" {
+		return fmt.Errorf("empty 'tag' parameter")
+	}
+
+	if err := c.requireExtension(tagExtensions...); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodPut, c.tagURL(resource, id, tag), nil, http.StatusCreated, nil)
+}
+
+// ReplaceTags replaces all tags of the resource with the given tags.
+func (c *Client) ReplaceTags(resource, id string, tags []string) ([]string, error) {
+	if err := checkTagParams(resource, id); err != nil {
+		return nil, err
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+
+	if err := c.requireExtension(tagExtensions...); err != nil {
+		return nil, err
+	}
+
+	var r Tags
+	err := c.send(http.MethodPut, c.tagsURL(resource, id), Tags{Tags: tags}, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.Tags, nil
+}
+
+func (c *Client) RemoveTag(resource, id, tag string) error {
+	if err := checkTagParams(resource, id); err != nil {
+		return err
+	}
+	if tag == "" {
+		return fmt.Errorf("empty 'tag' parameter")
+	}
+
+	if err := c.requireExtension(tagExtensions...); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, c.tagURL(resource, id, tag), nil, http.StatusNoContent, nil)
+}
+
+// ClearTags removes all tags from the resource.
+func (c *Client) ClearTags(resource, id string) error {
+	if err := checkTagParams(resource, id); err != nil {
+		return err
+	}
+
+	if err := c.requireExtension(tagExtensions...); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, c.tagsURL(resource, id), nil, http.StatusNoContent, nil)
+}
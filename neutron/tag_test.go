@@ -0,0 +1,150 @@
+package neutron_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const tagsResp = `{
+  "tags": ["red", "blue"]
+}`
+
+var _ = Describe("Tags", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		method string
+		path   string
+		query  url.Values
+		body   []byte
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			path = r.URL.EscapedPath()
+			query = r.URL.Query()
+			body, _ = ioutil.ReadAll(r.Body)
+			switch {
+			case r.URL.Path == "/v2.0/extensions":
+				fmt.Fprintln(w, extensionsResp)
+			case r.URL.Path == "/v2.0/networks" || r.URL.Path == "/v2.0/subnets":
+				fmt.Fprintln(w, networksEmpty)
+			case r.URL.Path == "/v2.0/ports/port1/tags/missing":
+				w.WriteHeader(http.StatusNotFound)
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == http.MethodPut && r.URL.Path == "/v2.0/ports/port1/tags/red":
+				w.WriteHeader(http.StatusCreated)
+			case r.Method == http.MethodGet && r.URL.Path == "/v2.0/ports/port1/tags/red":
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				fmt.Fprintln(w, tagsResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists the tags of a resource", func() {
+		tags, err := client.Tags(neutron.NetworksResource, "network1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(path).To(Equal("/v2.0/networks/network1/tags"))
+		Expect(tags).To(Equal([]string{"red", "blue"}))
+	})
+
+	It("adds a tag to a resource", func() {
+		err := client.AddTag(neutron.PortsResource, "port1", "red")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/ports/port1/tags/red"))
+	})
+
+	It("escapes tags in the path", func() {
+		client.RemoveTag(neutron.RoutersResource, "router1", "a/b c")
+		Expect(path).To(Equal("/v2.0/routers/router1/tags/a%2Fb%20c"))
+	})
+
+	It("checks whether a resource has a tag", func() {
+		ok, err := client.HasTag(neutron.PortsResource, "port1", "red")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		ok, err = client.HasTag(neutron.PortsResource, "port1", "missing")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("replaces all tags of a resource", func() {
+		tags, err := client.ReplaceTags(neutron.SubnetsResource, "subnet1", []string{"red", "blue"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodPut))
+		Expect(path).To(Equal("/v2.0/subnets/subnet1/tags"))
+		Expect(body).To(MatchJSON(`{"tags": ["red", "blue"]}`))
+		Expect(tags).To(Equal([]string{"red", "blue"}))
+	})
+
+	It("removes a tag from a resource", func() {
+		err := client.RemoveTag(neutron.PortsResource, "port1", "red")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/ports/port1/tags/red"))
+	})
+
+	It("clears all tags of a resource", func() {
+		err := client.ClearTags(neutron.NetworksResource, "network1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(method).To(Equal(http.MethodDelete))
+		Expect(path).To(Equal("/v2.0/networks/network1/tags"))
+	})
+
+	Context("when id is invalid", func() {
+		It("returns an error", func() {
+			err := client.AddTag(neutron.NetworksResource, "", "red")
+			Expect(err).To(MatchError("empty 'id' parameter"))
+		})
+	})
+
+	Context("when tag is invalid", func() {
+		It("returns an error", func() {
+			err := client.AddTag(neutron.NetworksResource, "network1", "")
+			Expect(err).To(MatchError("empty 'tag' parameter"))
+		})
+	})
+
+	Describe("tag filters", func() {
+		It("filters list calls by tags", func() {
+			_, err := client.Networks(neutron.ListOpts{
+				Tags:       []string{"red", "blue"},
+				TagsAny:    []string{"green"},
+				NotTags:    []string{"old"},
+				NotTagsAny: []string{"x", "y"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(query.Get("tags")).To(Equal("red,blue"))
+			Expect(query.Get("tags-any")).To(Equal("green"))
+			Expect(query.Get("not-tags")).To(Equal("old"))
+			Expect(query.Get("not-tags-any")).To(Equal("x,y"))
+		})
+
+		It("combines name and tag filters", func() {
+			_, err := client.SubnetsByName("subnet1", neutron.ListOpts{Tags: []string{"red"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(query.Get("name")).To(Equal("subnet1"))
+			Expect(query.Get("tags")).To(Equal("red"))
+		})
+	})
+})
@@ -0,0 +1,83 @@
+package neutron
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timestampLayout is the format of the timestamps of older Neutron releases,
+// which omit the time zone; they are in UTC.
+const timestampLayout = "2006-01-02T15:04:05"
+
+type timestamps struct {
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func parseTimestamp(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp '%s'", s)
+	}
+	return t, nil
+}
+
+// parseTimestamps reads the created_at and updated_at attributes of a
+// resource.
+func parseTimestamps(data []byte) (created, updated time.Time, err error) {
+	var ts timestamps
+	if err = json.Unmarshal(data, &ts); err != nil {
+		return
+	}
+	if created, err = parseTimestamp(ts.CreatedAt); err != nil {
+		return
+	}
+	updated, err = parseTimestamp(ts.UpdatedAt)
+	return
+}
+
+func (n *Network) UnmarshalJSON(data []byte) error {
+	type plain Network
+	if err := unmarshalExtras(data, (*plain)(n), &n.Extras, "created_at", "updated_at", "revision_number"); err != nil {
+		return err
+	}
+	var err error
+	if n.CreatedAt, n.UpdatedAt, err = parseTimestamps(data); err != nil {
+		return err
+	}
+	n.RevisionNumber, err = parseRevision(data)
+	return err
+}
+
+func (s *Subnet) UnmarshalJSON(data []byte) error {
+	type plain Subnet
+	if err := unmarshalExtras(data, (*plain)(s), &s.Extras, "created_at", "updated_at", "revision_number"); err != nil {
+		return err
+	}
+	var err error
+	if s.CreatedAt, s.UpdatedAt, err = parseTimestamps(data); err != nil {
+		return err
+	}
+	s.RevisionNumber, err = parseRevision(data)
+	return err
+}
+
+func (p *Port) UnmarshalJSON(data []byte) error {
+	type plain Port
+	if err := unmarshalExtras(data, (*plain)(p), &p.Extras, "created_at", "updated_at", "revision_number"); err != nil {
+		return err
+	}
+	var err error
+	if p.CreatedAt, p.UpdatedAt, err = parseTimestamps(data); err != nil {
+		return err
+	}
+	p.RevisionNumber, err = parseRevision(data)
+	return err
+}
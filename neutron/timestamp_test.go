@@ -0,0 +1,90 @@
+package neutron_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const timestampedPortsResp = `{
+  "ports": [
+    {
+      "id": "port1",
+      "network_id": "net1",
+      "created_at": "2016-03-08T20:19:41Z",
+      "updated_at": "2016-03-08T20:19:42Z"
+    },
+    {
+      "id": "port2",
+      "network_id": "net1",
+      "created_at": "2016-03-08T20:19:41",
+      "updated_at": null
+    }
+  ]
+}`
+
+var _ = Describe("Timestamps", func() {
+	var (
+		client *neutron.Client
+		server *httptest.Server
+		query  url.Values
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			switch r.URL.Path {
+			case "/v2.0/networks/net1":
+				fmt.Fprintln(w, `{"network": {"id": "net1", "created_at": "2017-01-12T09:00:00Z", "updated_at": "bogus"}}`)
+			default:
+				fmt.Fprintln(w, timestampedPortsResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reads created_at and updated_at", func() {
+		ports, err := client.Ports()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ports[0].CreatedAt).To(Equal(time.Date(2016, 3, 8, 20, 19, 41, 0, time.UTC)))
+		Expect(ports[0].UpdatedAt).To(Equal(time.Date(2016, 3, 8, 20, 19, 42, 0, time.UTC)))
+	})
+
+	It("reads timestamps without time zone as UTC", func() {
+		ports, err := client.Ports()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ports[1].CreatedAt).To(Equal(time.Date(2016, 3, 8, 20, 19, 41, 0, time.UTC)))
+		Expect(ports[1].UpdatedAt.IsZero()).To(BeTrue())
+	})
+
+	It("rejects invalid timestamps", func() {
+		_, err := client.Network("net1")
+		Expect(err).To(MatchError("invalid timestamp 'bogus'"))
+	})
+
+	It("never sends timestamps", func() {
+		p := neutron.Port{NetworkID: "net1", CreatedAt: time.Now()}
+		Expect(json.Marshal(p)).ToNot(ContainSubstring("created_at"))
+	})
+
+	It("filters lists by change time", func() {
+		since := time.Date(2017, 1, 12, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+		_, err := client.Subnets(neutron.ListOpts{ChangedSince: since})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(query.Get("changed_since")).To(Equal("2017-01-12T09:00:00Z"))
+	})
+})
@@ -0,0 +1,733 @@
+package neutron
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type Lifetime struct {
+	Units string `json:"units,omitempty"`
+	Value int    `json:"value,omitempty"`
+}
+
+type IKEPolicy struct {
+	ID                    string    `json:"id,omitempty"`
+	Name                  string    `json:"name,omitempty"`
+	Description           string    `json:"description,omitempty"`
+	AuthAlgorithm         string    `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm   string    `json:"encryption_algorithm,omitempty"`
+	Phase1NegotiationMode string    `json:"phase1_negotiation_mode,omitempty"`
+	IKEVersion            string    `json:"ike_version,omitempty"`
+	PFS                   string    `json:"pfs,omitempty"`
+	Lifetime              *Lifetime `json:"lifetime,omitempty"`
+	TenantID              string    `json:"tenant_id,omitempty"`
+	ProjectID             string    `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetIKEPolicies struct {
+	IKEPolicies []IKEPolicy `json:"ikepolicies"`
+}
+
+type SingleIKEPolicy struct {
+	IKEPolicy IKEPolicy `json:"ikepolicy"`
+}
+
+// IKEPolicyUpdate holds the IKE policy attributes to change; nil fields are
+// left as is.
+type IKEPolicyUpdate struct {
+	Name                  *string   `json:"name,omitempty"`
+	Description           *string   `json:"description,omitempty"`
+	AuthAlgorithm         *string   `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm   *string   `json:"encryption_algorithm,omitempty"`
+	Phase1NegotiationMode *string   `json:"phase1_negotiation_mode,omitempty"`
+	IKEVersion            *string   `json:"ike_version,omitempty"`
+	PFS                   *string   `json:"pfs,omitempty"`
+	Lifetime              *Lifetime `json:"lifetime,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type IPsecPolicy struct {
+	ID                  string    `json:"id,omitempty"`
+	Name                string    `json:"name,omitempty"`
+	Description         string    `json:"description,omitempty"`
+	AuthAlgorithm       string    `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm string    `json:"encryption_algorithm,omitempty"`
+	TransformProtocol   string    `json:"transform_protocol,omitempty"`
+	EncapsulationMode   string    `json:"encapsulation_mode,omitempty"`
+	PFS                 string    `json:"pfs,omitempty"`
+	Lifetime            *Lifetime `json:"lifetime,omitempty"`
+	TenantID            string    `json:"tenant_id,omitempty"`
+	ProjectID           string    `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetIPsecPolicies struct {
+	IPsecPolicies []IPsecPolicy `json:"ipsecpolicies"`
+}
+
+type SingleIPsecPolicy struct {
+	IPsecPolicy IPsecPolicy `json:"ipsecpolicy"`
+}
+
+// IPsecPolicyUpdate holds the IPsec policy attributes to change; nil fields
+// are left as is.
+type IPsecPolicyUpdate struct {
+	Name                *string   `json:"name,omitempty"`
+	Description         *string   `json:"description,omitempty"`
+	AuthAlgorithm       *string   `json:"auth_algorithm,omitempty"`
+	EncryptionAlgorithm *string   `json:"encryption_algorithm,omitempty"`
+	TransformProtocol   *string   `json:"transform_protocol,omitempty"`
+	EncapsulationMode   *string   `json:"encapsulation_mode,omitempty"`
+	PFS                 *string   `json:"pfs,omitempty"`
+	Lifetime            *Lifetime `json:"lifetime,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type VPNService struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	RouterID     string `json:"router_id"`
+	SubnetID     string `json:"subnet_id,omitempty"`
+	AdminStateUp *bool  `json:"admin_state_up,omitempty"`
+	Status       string `json:"status,omitempty"`
+	ExternalV4IP string `json:"external_v4_ip,omitempty"`
+	ExternalV6IP string `json:"external_v6_ip,omitempty"`
+	TenantID     string `json:"tenant_id,omitempty"`
+	ProjectID    string `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetVPNServices struct {
+	VPNServices []VPNService `json:"vpnservices"`
+}
+
+type SingleVPNService struct {
+	VPNService VPNService `json:"vpnservice"`
+}
+
+// VPNServiceUpdate holds the VPN service attributes to change; nil fields are
+// left as is.
+type VPNServiceUpdate struct {
+	Name         *string `json:"name,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	AdminStateUp *bool   `json:"admin_state_up,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+const (
+	EndpointGroupTypeSubnet = "subnet"
+	EndpointGroupTypeCIDR   = "cidr"
+)
+
+// EndpointGroup is a set of subnet IDs or CIDRs, depending on Type, used as
+// the local or peer side of a site connection.
+type EndpointGroup struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Endpoints   []string `json:"endpoints,omitempty"`
+	TenantID    string   `json:"tenant_id,omitempty"`
+	ProjectID   string   `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetEndpointGroups struct {
+	EndpointGroups []EndpointGroup `json:"endpoint_groups"`
+}
+
+type SingleEndpointGroup struct {
+	EndpointGroup EndpointGroup `json:"endpoint_group"`
+}
+
+// EndpointGroupUpdate holds the endpoint group attributes to change; nil
+// fields are left as is.
+type EndpointGroupUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type DPD struct {
+	Action   string `json:"action,omitempty"`
+	Interval int    `json:"interval,omitempty"`
+	Timeout  int    `json:"timeout,omitempty"`
+}
+
+type IPsecSiteConnection struct {
+	ID             string   `json:"id,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	PeerAddress    string   `json:"peer_address"`
+	PeerID         string   `json:"peer_id"`
+	PeerCIDRs      []string `json:"peer_cidrs,omitempty"`
+	LocalID        string   `json:"local_id,omitempty"`
+	LocalEPGroupID string   `json:"local_ep_group_id,omitempty"`
+	PeerEPGroupID  string   `json:"peer_ep_group_id,omitempty"`
+	PSK            string   `json:"psk"`
+	MTU            int      `json:"mtu,omitempty"`
+	Initiator      string   `json:"initiator,omitempty"`
+	AdminStateUp   *bool    `json:"admin_state_up,omitempty"`
+	DPD            *DPD     `json:"dpd,omitempty"`
+	IKEPolicyID    string   `json:"ikepolicy_id"`
+	IPsecPolicyID  string   `json:"ipsecpolicy_id"`
+	VPNServiceID   string   `json:"vpnservice_id"`
+	RouteMode      string   `json:"route_mode,omitempty"`
+	AuthMode       string   `json:"auth_mode,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	TenantID       string   `json:"tenant_id,omitempty"`
+	ProjectID      string   `json:"project_id,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type GetIPsecSiteConnections struct {
+	IPsecSiteConnections []IPsecSiteConnection `json:"ipsec_site_connections"`
+}
+
+type SingleIPsecSiteConnection struct {
+	IPsecSiteConnection IPsecSiteConnection `json:"ipsec_site_connection"`
+}
+
+// IPsecSiteConnectionUpdate holds the site connection attributes to change;
+// nil fields are left as is.
+type IPsecSiteConnectionUpdate struct {
+	Name           *string `json:"name,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	PeerAddress    *string `json:"peer_address,omitempty"`
+	PeerID         *string `json:"peer_id,omitempty"`
+	LocalID        *string `json:"local_id,omitempty"`
+	LocalEPGroupID *string `json:"local_ep_group_id,omitempty"`
+	PeerEPGroupID  *string `json:"peer_ep_group_id,omitempty"`
+	PSK            *string `json:"psk,omitempty"`
+	MTU            *int    `json:"mtu,omitempty"`
+	Initiator      *string `json:"initiator,omitempty"`
+	AdminStateUp   *bool   `json:"admin_state_up,omitempty"`
+	DPD            *DPD    `json:"dpd,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+const vpnExtension = "vpnaas"
+
+func (c *Client) IKEPolicies(opts ...ListOpts) ([]IKEPolicy, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetIKEPolicies
+	err := c.send(http.MethodGet, c.listURL("vpn/ikepolicies", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.IKEPolicies, nil
+}
+
+func (c *Client) IKEPolicy(id string) (IKEPolicy, error) {
+	if id == "" {
+		return IKEPolicy{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return IKEPolicy{}, err
+	}
+
+	var r SingleIKEPolicy
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/vpn/ikepolicies/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return IKEPolicy{}, err
+	}
+	return r.IKEPolicy, nil
+}
+
+func (c *Client) CreateIKEPolicy(p IKEPolicy) (IKEPolicy, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return IKEPolicy{}, err
+	}
+
+	var r SingleIKEPolicy
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/vpn/ikepolicies", c.URL), SingleIKEPolicy{IKEPolicy: p}, http.StatusCreated, &r)
+	if err != nil {
+		return IKEPolicy{}, err
+	}
+	return r.IKEPolicy, nil
+}
+
+func (c *Client) UpdateIKEPolicy(id string, u IKEPolicyUpdate) (IKEPolicy, error) {
+	if id == "" {
+		return IKEPolicy{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return IKEPolicy{}, err
+	}
+
+	var r SingleIKEPolicy
+	body := map[string]IKEPolicyUpdate{"ikepolicy": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/vpn/ikepolicies/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return IKEPolicy{}, err
+	}
+	return r.IKEPolicy, nil
+}
+
+func (c *Client) DeleteIKEPolicy(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/vpn/ikepolicies/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) IPsecPolicies(opts ...ListOpts) ([]IPsecPolicy, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetIPsecPolicies
+	err := c.send(http.MethodGet, c.listURL("vpn/ipsecpolicies", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.IPsecPolicies, nil
+}
+
+func (c *Client) IPsecPolicy(id string) (IPsecPolicy, error) {
+	if id == "" {
+		return IPsecPolicy{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return IPsecPolicy{}, err
+	}
+
+	var r SingleIPsecPolicy
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/vpn/ipsecpolicies/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return IPsecPolicy{}, err
+	}
+	return r.IPsecPolicy, nil
+}
+
+func (c *Client) CreateIPsecPolicy(p IPsecPolicy) (IPsecPolicy, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return IPsecPolicy{}, err
+	}
+
+	var r SingleIPsecPolicy
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/vpn/ipsecpolicies", c.URL), SingleIPsecPolicy{IPsecPolicy: p}, http.StatusCreated, &r)
+	if err != nil {
+		return IPsecPolicy{}, err
+	}
+	return r.IPsecPolicy, nil
+}
+
+func (c *Client) UpdateIPsecPolicy(id string, u IPsecPolicyUpdate) (IPsecPolicy, error) {
+	if id == "" {
+		return IPsecPolicy{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return IPsecPolicy{}, err
+	}
+
+	var r SingleIPsecPolicy
+	body := map[string]IPsecPolicyUpdate{"ipsecpolicy": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/vpn/ipsecpolicies/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return IPsecPolicy{}, err
+	}
+	return r.IPsecPolicy, nil
+}
+
+func (c *Client) DeleteIPsecPolicy(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/vpn/ipsecpolicies/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) VPNServices(opts ...ListOpts) ([]VPNService, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetVPNServices
+	err := c.send(http.MethodGet, c.listURL("vpn/vpnservices", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.VPNServices, nil
+}
+
+func (c *Client) VPNService(id string) (VPNService, error) {
+	if id == "" {
+		return VPNService{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return VPNService{}, err
+	}
+
+	var r SingleVPNService
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/vpn/vpnservices/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return VPNService{}, err
+	}
+	return r.VPNService, nil
+}
+
+func (c *Client) CreateVPNService(s VPNService) (VPNService, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return VPNService{}, err
+	}
+
+	var r SingleVPNService
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/vpn/vpnservices", c.URL), SingleVPNService{VPNService: s}, http.StatusCreated, &r)
+	if err != nil {
+		return VPNService{}, err
+	}
+	return r.VPNService, nil
+}
+
+func (c *Client) UpdateVPNService(id string, u VPNServiceUpdate) (VPNService, error) {
+	if id == "" {
+		return VPNService{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return VPNService{}, err
+	}
+
+	var r SingleVPNService
+	body := map[string]VPNServiceUpdate{"vpnservice": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/vpn/vpnservices/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return VPNService{}, err
+	}
+	return r.VPNService, nil
+}
+
+func (c *Client) DeleteVPNService(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/vpn/vpnservices/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) EndpointGroups(opts ...ListOpts) ([]EndpointGroup, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetEndpointGroups
+	err := c.send(http.MethodGet, c.listURL("vpn/endpoint-groups", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.EndpointGroups, nil
+}
+
+func (c *Client) EndpointGroup(id string) (EndpointGroup, error) {
+	if id == "" {
+		return EndpointGroup{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return EndpointGroup{}, err
+	}
+
+	var r SingleEndpointGroup
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/vpn/endpoint-groups/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return EndpointGroup{}, err
+	}
+	return r.EndpointGroup, nil
+}
+
+func (c *Client) CreateEndpointGroup(g EndpointGroup) (EndpointGroup, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return EndpointGroup{}, err
+	}
+
+	var r SingleEndpointGroup
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/vpn/endpoint-groups", c.URL), SingleEndpointGroup{EndpointGroup: g}, http.StatusCreated, &r)
+	if err != nil {
+		return EndpointGroup{}, err
+	}
+	return r.EndpointGroup, nil
+}
+
+func (c *Client) UpdateEndpointGroup(id string, u EndpointGroupUpdate) (EndpointGroup, error) {
+	if id == "" {
+		return EndpointGroup{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return EndpointGroup{}, err
+	}
+
+	var r SingleEndpointGroup
+	body := map[string]EndpointGroupUpdate{"endpoint_group": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/vpn/endpoint-groups/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return EndpointGroup{}, err
+	}
+	return r.EndpointGroup, nil
+}
+
+func (c *Client) DeleteEndpointGroup(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/vpn/endpoint-groups/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+func (c *Client) IPsecSiteConnections(opts ...ListOpts) ([]IPsecSiteConnection, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return nil, err
+	}
+
+	var r GetIPsecSiteConnections
+	err := c.send(http.MethodGet, c.listURL("vpn/ipsec-site-connections", nil, opts), nil, http.StatusOK, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.IPsecSiteConnections, nil
+}
+
+func (c *Client) IPsecSiteConnection(id string) (IPsecSiteConnection, error) {
+	if id == "" {
+		return IPsecSiteConnection{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return IPsecSiteConnection{}, err
+	}
+
+	var r SingleIPsecSiteConnection
+	err := c.send(http.MethodGet, fmt.Sprintf("%s/v2.0/vpn/ipsec-site-connections/%s", c.URL, id), nil, http.StatusOK, &r)
+	if err != nil {
+		return IPsecSiteConnection{}, err
+	}
+	return r.IPsecSiteConnection, nil
+}
+
+func (c *Client) CreateIPsecSiteConnection(conn IPsecSiteConnection) (IPsecSiteConnection, error) {
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return IPsecSiteConnection{}, err
+	}
+
+	var r SingleIPsecSiteConnection
+	err := c.send(http.MethodPost, fmt.Sprintf("%s/v2.0/vpn/ipsec-site-connections", c.URL), SingleIPsecSiteConnection{IPsecSiteConnection: conn}, http.StatusCreated, &r)
+	if err != nil {
+		return IPsecSiteConnection{}, err
+	}
+	return r.IPsecSiteConnection, nil
+}
+
+func (c *Client) UpdateIPsecSiteConnection(id string, u IPsecSiteConnectionUpdate) (IPsecSiteConnection, error) {
+	if id == "" {
+		return IPsecSiteConnection{}, fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return IPsecSiteConnection{}, err
+	}
+
+	var r SingleIPsecSiteConnection
+	body := map[string]IPsecSiteConnectionUpdate{"ipsec_site_connection": u}
+	err := c.send(http.MethodPut, fmt.Sprintf("%s/v2.0/vpn/ipsec-site-connections/%s", c.URL, id), body, http.StatusOK, &r)
+	if err != nil {
+		return IPsecSiteConnection{}, err
+	}
+	return r.IPsecSiteConnection, nil
+}
+
+func (c *Client) DeleteIPsecSiteConnection(id string) error {
+	if id == "" {
+		return fmt.Errorf("empty 'id' parameter")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return err
+	}
+
+	return c.send(http.MethodDelete, fmt.Sprintf("%s/v2.0/vpn/ipsec-site-connections/%s", c.URL, id), nil, http.StatusNoContent, nil)
+}
+
+// SiteConnectionSpec describes a complete site-to-site connection between
+// local subnets behind a router and the peer CIDRs behind a remote gateway.
+type SiteConnectionSpec struct {
+	Name           string
+	RouterID       string
+	LocalSubnetIDs []string
+	PeerCIDRs      []string
+	PeerAddress    string
+	// PeerID defaults to PeerAddress.
+	PeerID      string
+	PSK         string
+	MTU         int
+	Initiator   string
+	DPD         *DPD
+	IKEPolicy   IKEPolicy
+	IPsecPolicy IPsecPolicy
+}
+
+// SiteConnection holds the resources created by CreateSiteConnection.
+type SiteConnection struct {
+	IKEPolicy          IKEPolicy
+	IPsecPolicy        IPsecPolicy
+	VPNService         VPNService
+	LocalEndpointGroup EndpointGroup
+	PeerEndpointGroup  EndpointGroup
+	Connection         IPsecSiteConnection
+}
+
+// CreateSiteConnection creates the IKE and IPsec policies, a VPN service on
+// the router, the local and peer endpoint groups and finally the site
+// connection. If a step fails, the resources already created are deleted.
+func (c *Client) CreateSiteConnection(spec SiteConnectionSpec) (SiteConnection, error) {
+	switch {
+	case spec.RouterID == "":
+		return SiteConnection{}, fmt.Errorf("missing router id")
+	case len(spec.LocalSubnetIDs) == 0:
+		return SiteConnection{}, fmt.Errorf("missing local subnets")
+	case len(spec.PeerCIDRs) == 0:
+		return SiteConnection{}, fmt.Errorf("missing peer CIDRs")
+	case spec.PeerAddress == "":
+		return SiteConnection{}, fmt.Errorf("missing peer address")
+	case spec.PSK == "":
+		return SiteConnection{}, fmt.Errorf("missing pre-shared key")
+	}
+	if err := c.requireExtension(vpnExtension); err != nil {
+		return SiteConnection{}, err
+	}
+
+	var (
+		sc       SiteConnection
+		rollback rollback
+		err      error
+		up       = true
+	)
+	fail := func(err error) (SiteConnection, error) {
+		return SiteConnection{}, rollback.run(err)
+	}
+	suffixed := func(suffix string) string {
+		if spec.Name == "" {
+			return ""
+		}
+		return spec.Name + suffix
+	}
+
+	ike := spec.IKEPolicy
+	if ike.Name == "" {
+		ike.Name = spec.Name
+	}
+	if sc.IKEPolicy, err = c.CreateIKEPolicy(ike); err != nil {
+		return fail(err)
+	}
+	rollback = append(rollback, func() error { return c.DeleteIKEPolicy(sc.IKEPolicy.ID) })
+
+	ipsec := spec.IPsecPolicy
+	if ipsec.Name == "" {
+		ipsec.Name = spec.Name
+	}
+	if sc.IPsecPolicy, err = c.CreateIPsecPolicy(ipsec); err != nil {
+		return fail(err)
+	}
+	rollback = append(rollback, func() error { return c.DeleteIPsecPolicy(sc.IPsecPolicy.ID) })
+
+	sc.VPNService, err = c.CreateVPNService(VPNService{
+		Name:         spec.Name,
+		RouterID:     spec.RouterID,
+		AdminStateUp: &up,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	rollback = append(rollback, func() error { return c.DeleteVPNService(sc.VPNService.ID) })
+
+	sc.LocalEndpointGroup, err = c.CreateEndpointGroup(EndpointGroup{
+		Name:      suffixed("-local"),
+		Type:      EndpointGroupTypeSubnet,
+		Endpoints: spec.LocalSubnetIDs,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	rollback = append(rollback, func() error { return c.DeleteEndpointGroup(sc.LocalEndpointGroup.ID) })
+
+	sc.PeerEndpointGroup, err = c.CreateEndpointGroup(EndpointGroup{
+		Name:      suffixed("-peer"),
+		Type:      EndpointGroupTypeCIDR,
+		Endpoints: spec.PeerCIDRs,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	rollback = append(rollback, func() error { return c.DeleteEndpointGroup(sc.PeerEndpointGroup.ID) })
+
+	peerID := spec.PeerID
+	if peerID == "" {
+		peerID = spec.PeerAddress
+	}
+	sc.Connection, err = c.CreateIPsecSiteConnection(IPsecSiteConnection{
+		Name:           spec.Name,
+		PeerAddress:    spec.PeerAddress,
+		PeerID:         peerID,
+		PSK:            spec.PSK,
+		MTU:            spec.MTU,
+		Initiator:      spec.Initiator,
+		DPD:            spec.DPD,
+		AdminStateUp:   &up,
+		IKEPolicyID:    sc.IKEPolicy.ID,
+		IPsecPolicyID:  sc.IPsecPolicy.ID,
+		VPNServiceID:   sc.VPNService.ID,
+		LocalEPGroupID: sc.LocalEndpointGroup.ID,
+		PeerEPGroupID:  sc.PeerEndpointGroup.ID,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	return sc, nil
+}
+
+// DeleteSiteConnection deletes the resources of a site connection in the
+// reverse order of their creation.
+func (c *Client) DeleteSiteConnection(sc SiteConnection) error {
+	steps := []struct {
+		id     string
+		delete func(string) error
+	}{
+		{sc.Connection.ID, c.DeleteIPsecSiteConnection},
+		{sc.PeerEndpointGroup.ID, c.DeleteEndpointGroup},
+		{sc.LocalEndpointGroup.ID, c.DeleteEndpointGroup},
+		{sc.VPNService.ID, c.DeleteVPNService},
+		{sc.IPsecPolicy.ID, c.DeleteIPsecPolicy},
+		{sc.IKEPolicy.ID, c.DeleteIKEPolicy},
+	}
+	for _, s := range steps {
+		if s.id == "" {
+			continue
+		}
+		if err := s.delete(s.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package neutron_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/markstgodard/go-neutron/neutron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const ikePoliciesResp = `{
+  "ikepolicies": [
+    {
+      "id": "5522aff7-1b3c-48dd-9c3c-b50f016b73db",
+      "name": "ikepolicy1",
+      "auth_algorithm": "sha1",
+      "encryption_algorithm": "aes-256",
+      "pfs": "group5",
+      "phase1_negotiation_mode": "main",
+      "ike_version": "v1",
+      "lifetime": {
+        "units": "seconds",
+        "value": 3600
+      }
+    }
+  ]
+}`
+
+var _ = Describe("VPN", func() {
+	var (
+		client   *neutron.Client
+		server   *httptest.Server
+		requests []string
+		bodies   map[string][]byte
+		failOn   string
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		bodies = map[string][]byte{}
+		failOn = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2.0/extensions" {
+				fmt.Fprintln(w, extensionsResp)
+				return
+			}
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies[r.Method+" "+r.URL.Path] = body
+
+			if r.URL.Path == failOn {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			switch r.Method {
+			case http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodPost:
+				// echo the resource back with an id derived from its collection
+				var in map[string]map[string]interface{}
+				json.Unmarshal(body, &in)
+				for key, res := range in {
+					res["id"] = key + "-id"
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(map[string]interface{}{key: res})
+				}
+			default:
+				fmt.Fprintln(w, ikePoliciesResp)
+			}
+		}))
+		var err error
+		client, err = neutron.NewClient(server.URL, "some-token")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists IKE policies", func() {
+		policies, err := client.IKEPolicies()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal([]string{"GET /v2.0/vpn/ikepolicies"}))
+		Expect(policies).To(HaveLen(1))
+		Expect(policies[0].EncryptionAlgorithm).To(Equal("aes-256"))
+		Expect(policies[0].Lifetime).To(Equal(&neutron.Lifetime{Units: "seconds", Value: 3600}))
+	})
+
+	It("creates an endpoint group", func() {
+		g, err := client.CreateEndpointGroup(neutron.EndpointGroup{
+			Name:      "peers",
+			Type:      neutron.EndpointGroupTypeCIDR,
+			Endpoints: []string{"10.2.0.0/24"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bodies["POST /v2.0/vpn/endpoint-groups"]).To(MatchJSON(`{
+			"endpoint_group": {"name": "peers", "type": "cidr", "endpoints": ["10.2.0.0/24"]}
+		}`))
+		Expect(g.ID).To(Equal("endpoint_group-id"))
+	})
+
+	It("creates a VPN service administratively down", func() {
+		down := false
+		s, err := client.CreateVPNService(neutron.VPNService{RouterID: "router1", AdminStateUp: &down})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bodies["POST /v2.0/vpn/vpnservices"]).To(MatchJSON(`{"vpnservice": {"router_id": "router1", "admin_state_up": false}}`))
+		Expect(*s.AdminStateUp).To(BeFalse())
+	})
+
+	It("updates a site connection", func() {
+		down := false
+		_, err := client.UpdateIPsecSiteConnection("conn1", neutron.IPsecSiteConnectionUpdate{AdminStateUp: &down})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bodies["PUT /v2.0/vpn/ipsec-site-connections/conn1"]).To(MatchJSON(`{"ipsec_site_connection": {"admin_state_up": false}}`))
+	})
+
+	It("deletes a VPN service", func() {
+		err := client.DeleteVPNService("vpn1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal([]string{"DELETE /v2.0/vpn/vpnservices/vpn1"}))
+	})
+
+	Describe("CreateSiteConnection", func() {
+		var spec neutron.SiteConnectionSpec
+
+		BeforeEach(func() {
+			spec = neutron.SiteConnectionSpec{
+				Name:           "branch1",
+				RouterID:       "router1",
+				LocalSubnetIDs: []string{"subnet1"},
+				PeerCIDRs:      []string{"10.2.0.0/24"},
+				PeerAddress:    "203.0.113.10",
+				PSK:            "secret",
+				IKEPolicy:      neutron.IKEPolicy{IKEVersion: "v2"},
+			}
+		})
+
+		It("creates all resources of the connection", func() {
+			sc, err := client.CreateSiteConnection(spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]string{
+				"POST /v2.0/vpn/ikepolicies",
+				"POST /v2.0/vpn/ipsecpolicies",
+				"POST /v2.0/vpn/vpnservices",
+				"POST /v2.0/vpn/endpoint-groups",
+				"POST /v2.0/vpn/endpoint-groups",
+				"POST /v2.0/vpn/ipsec-site-connections",
+			}))
+			Expect(bodies["POST /v2.0/vpn/ikepolicies"]).To(MatchJSON(`{"ikepolicy": {"name": "branch1", "ike_version": "v2"}}`))
+			Expect(bodies["POST /v2.0/vpn/ipsec-site-connections"]).To(MatchJSON(`{
+				"ipsec_site_connection": {
+					"name": "branch1",
+					"peer_address": "203.0.113.10",
+					"peer_id": "203.0.113.10",
+					"psk": "secret",
+					"admin_state_up": true,
+					"ikepolicy_id": "ikepolicy-id",
+					"ipsecpolicy_id": "ipsecpolicy-id",
+					"vpnservice_id": "vpnservice-id",
+					"local_ep_group_id": "endpoint_group-id",
+					"peer_ep_group_id": "endpoint_group-id"
+				}
+			}`))
+			Expect(sc.Connection.ID).To(Equal("ipsec_site_connection-id"))
+			Expect(sc.VPNService.RouterID).To(Equal("router1"))
+		})
+
+		It("rolls back created resources when a step fails", func() {
+			failOn = "/v2.0/vpn/ipsec-site-connections"
+			_, err := client.CreateSiteConnection(spec)
+			Expect(err).To(HaveOccurred())
+
+			var deletes []string
+			for _, r := range requests {
+				if strings.HasPrefix(r, "DELETE") {
+					deletes = append(deletes, r)
+				}
+			}
+			Expect(deletes).To(Equal([]string{
+				"DELETE /v2.0/vpn/endpoint-groups/endpoint_group-id",
+				"DELETE /v2.0/vpn/endpoint-groups/endpoint_group-id",
+				"DELETE /v2.0/vpn/vpnservices/vpnservice-id",
+				"DELETE /v2.0/vpn/ipsecpolicies/ipsecpolicy-id",
+				"DELETE /v2.0/vpn/ikepolicies/ikepolicy-id",
+			}))
+		})
+
+		It("validates the spec", func() {
+			spec.PSK = ""
+			_, err := client.CreateSiteConnection(spec)
+			Expect(err).To(MatchError("missing pre-shared key"))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})